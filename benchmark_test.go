@@ -44,4 +44,34 @@ func BenchmarkUUIDGeneration(b *testing.B) {
 		s := uuid.New()
 		_ = s.String()
 	}
-}
\ No newline at end of file
+}
+
+// Benchmark for ULID text parsing
+func BenchmarkUnmarshalText(b *testing.B) {
+	var (
+		id   ULID
+		text = []byte(MustNew().String())
+	)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := id.UnmarshalText(text); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Benchmark for oklog ULID text parsing
+func BenchmarkOklogUnmarshalText(b *testing.B) {
+	var (
+		id   oulid.ULID
+		text = []byte(MustNew().String())
+	)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := id.UnmarshalText(text); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+func runConvert(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var (
+		fs     = flag.NewFlagSet("convert", flag.ContinueOnError)
+		to     = fs.String("to", formatUUID, "output format: ulid, uuid or hex")
+		column = fs.String("column", "", "convert the CSV column, by header name or 1-based index")
+		field  = fs.String("field", "", "convert the NDJSON top level field")
+	)
+
+	fs.SetOutput(stderr)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if *column != "" && *field != "" {
+		_, _ = fmt.Fprintln(stderr, "--column and --field are mutually exclusive")
+		return exitUsage
+	}
+
+	if _, err := formatID(pulid.EmptyUID, *to); err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	status := exitOK
+	visit := func(_ int, value string) (string, error) {
+		id, err := parseID(value)
+		if err != nil {
+			return "", err
+		}
+
+		return formatID(id, *to)
+	}
+	report := func(line int, value string, err error) {
+		status = exitError
+		_, _ = fmt.Fprintf(stderr, "line %d: %s: %v\n", line, value, err)
+	}
+
+	if fs.NArg() > 0 {
+		out := bufio.NewWriter(stdout)
+		defer func() { _ = out.Flush() }()
+
+		for i, value := range fs.Args() {
+			converted, err := visit(i+1, value)
+			if err != nil {
+				status = exitError
+				_, _ = fmt.Fprintf(stderr, "%s: %v\n", value, err)
+				continue
+			}
+
+			_, _ = fmt.Fprintln(out, converted)
+		}
+
+		return status
+	}
+
+	if err := stream(stdin, stdout, streamOptions{column: *column, field: *field}, visit, report); err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	return status
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"time"
+
+	"github.com/pixie-sh/errors-go"
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+const (
+	formatULID = "ulid"
+	formatUUID = "uuid"
+	formatHex  = "hex"
+)
+
+// parseID accepts ULID, UUID or hex text
+func parseID(s string) (pulid.ULID, error) {
+	if len(s) == pulid.Hex.EncodedLen() {
+		return pulid.Decode(s, pulid.Hex)
+	}
+
+	return pulid.UnmarshalString(s)
+}
+
+func formatID(id pulid.ULID, format string) (string, error) {
+	switch format {
+	case formatULID:
+		return id.String(), nil
+	case formatUUID:
+		return id.UUID(), nil
+	case formatHex:
+		return id.Encode(pulid.Hex), nil
+	default:
+		return "", errors.New("unknown format %q; expected ulid, uuid or hex", format)
+	}
+}
+
+// timeLayouts accepted by parseTime, RFC3339 with optional seconds or a plain date
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04Z07:00", time.DateOnly}
+
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errors.New("invalid time %q; expected RFC3339, e.g. 2025-01-01T00:00Z", s)
+}
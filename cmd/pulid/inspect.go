@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+func runInspect(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 {
+		_, _ = fmt.Fprintln(stderr, "usage: pulid inspect <id>")
+		return exitUsage
+	}
+
+	id, err := parseID(fs.Arg(0))
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	scope, _ := id.Scope()
+	layout := pulid.DefaultLayout
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 1, ' ', 0)
+	_, _ = fmt.Fprintf(w, "ulid:\t%s\n", id.String())
+	_, _ = fmt.Fprintf(w, "uuid:\t%s\n", id.UUID())
+	_, _ = fmt.Fprintf(w, "hex:\t%s\n", id.Encode(pulid.Hex))
+	_, _ = fmt.Fprintf(w, "epoch:\t%d\n", id.Epoch())
+	_, _ = fmt.Fprintf(w, "time:\t%s\n", id.Time().UTC().Format(time.RFC3339Nano))
+	if name, ok := pulid.ScopeName(scope); ok {
+		_, _ = fmt.Fprintf(w, "scope:\t%d (%s)\n", scope, name)
+	} else {
+		_, _ = fmt.Fprintf(w, "scope:\t%d\n", scope)
+	}
+	_, _ = fmt.Fprintf(w, "entropy:\t%s\n", hex.EncodeToString(id[(128-int(layout.RandomBits))/8:]))
+
+	if err = w.Flush(); err != nil {
+		return exitError
+	}
+
+	return exitOK
+}
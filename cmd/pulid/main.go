@@ -0,0 +1,66 @@
+// Command pulid generates, inspects and converts pULIDs.
+//
+//	pulid new [--scope N] [--count N] [--at RFC3339] [--format ulid|uuid|hex]
+//	pulid inspect <id>
+//	pulid convert [--to ulid|uuid|hex] [--column name|N | --field name] [id...]
+//	pulid validate [--column name|N | --field name] [file...]
+//	pulid range --from RFC3339 [--to RFC3339] [--scope N]
+//
+// convert and validate read stdin when no id or file is passed: one id per line,
+// or, with --column, a CSV with header row or, with --field, NDJSON objects.
+// Ids are accepted as ULID, UUID or hex text. validate prints one line per invalid id,
+// with its line number and error code, and exits 1 when any is found.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+const usage = `usage: pulid <command> [flags]
+
+commands:
+  new      generate ids
+  inspect  print the fields of an id
+  convert  convert ids between ulid, uuid and hex text
+  validate report invalid ids, exiting 1 when any is found
+  range    print the min and max ids of a time window
+
+run 'pulid <command> -h' for the command flags
+`
+
+type command func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"new":      runNew,
+	"inspect":  runInspect,
+	"convert":  runConvert,
+	"validate": runValidate,
+	"range":    runRange,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+
+	return cmd(args[1:], stdin, stdout, stderr)
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+func runCLI(t *testing.T, stdin string, args ...string) (string, string, int) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+
+	return stdout.String(), stderr.String(), code
+}
+
+func TestNew(t *testing.T) {
+	out, errOut, code := runCLI(t, "", "new", "--scope", "567", "--count", "3", "--at", "2025-01-27T23:18:08.350Z", "--format", "uuid")
+	if code != exitOK {
+		t.Fatalf("Expected exit 0, got %d: %s", code, errOut)
+	}
+
+	lines := strings.Fields(out)
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 ids, got %q", out)
+	}
+
+	for _, line := range lines {
+		id, err := pulid.UnmarshalUUID(line)
+		if err != nil {
+			t.Fatalf("Invalid uuid %s: %v", line, err)
+		}
+
+		if scope, _ := id.Scope(); scope != 567 || id.Epoch() != 1738019888350 {
+			t.Fatalf("Unexpected fields on %s: scope %d epoch %d", line, scope, id.Epoch())
+		}
+	}
+
+	for _, args := range [][]string{
+		{"new", "--scope", "70000"},
+		{"new", "--format", "base64"},
+		{"new", "--at", "yesterday"},
+		{"unknown"},
+		{},
+	} {
+		if _, _, code = runCLI(t, "", args...); code != exitUsage {
+			t.Fatalf("Expected usage exit for %v, got %d", args, code)
+		}
+	}
+
+	for _, count := range []string{"0", "-1"} {
+		out, errOut, code = runCLI(t, "", "new", "--count", count)
+		if code != exitUsage || out != "" || !strings.Contains(errOut, "count "+count+" must be at least 1") {
+			t.Fatalf("Expected usage exit for --count %s, got %d: %q %q", count, code, out, errOut)
+		}
+	}
+}
+
+func TestInspect(t *testing.T) {
+	if err := pulid.RegisterScopeName(567, "usr"); err != nil {
+		t.Fatalf("Failed to register scope name: %v", err)
+	}
+
+	at := time.Date(2025, 1, 27, 23, 18, 8, 350*int(time.Millisecond), time.UTC)
+	id, _ := pulid.NewScopedAt(at, 567)
+
+	for _, input := range []string{id.String(), id.UUID(), id.Encode(pulid.Hex)} {
+		out, errOut, code := runCLI(t, "", "inspect", input)
+		if code != exitOK {
+			t.Fatalf("Expected exit 0 for %s, got %d: %s", input, code, errOut)
+		}
+
+		for _, want := range []string{id.String(), id.UUID(), "1738019888350", "2025-01-27T23:18:08.35Z", "567 (usr)"} {
+			if !strings.Contains(out, want) {
+				t.Fatalf("Inspect output should contain %s, got:\n%s", want, out)
+			}
+		}
+	}
+
+	if _, _, code := runCLI(t, "", "inspect", "not-an-id"); code != exitError {
+		t.Fatalf("Expected error exit for invalid id, got %d", code)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	a, b := pulid.MustNew(), pulid.MustNew()
+
+	out, _, code := runCLI(t, a.UUID()+"\n\n"+b.UUID()+"\n", "convert", "--to", "ulid")
+	if code != exitOK || out != a.String()+"\n\n"+b.String()+"\n" {
+		t.Fatalf("Unexpected stdin conversion (exit %d): %q", code, out)
+	}
+
+	out, _, code = runCLI(t, "", "convert", "--to", "hex", a.String())
+	if code != exitOK || out != a.Encode(pulid.Hex)+"\n" {
+		t.Fatalf("Unexpected args conversion (exit %d): %q", code, out)
+	}
+
+	out, errOut, code := runCLI(t, "bad\n"+a.String()+"\n", "convert")
+	if code != exitError || out != "\n"+a.UUID()+"\n" || !strings.Contains(errOut, "bad") {
+		t.Fatalf("Expected error exit keeping the rows aligned, got %d: %q %q", code, out, errOut)
+	}
+}
+
+func TestRange(t *testing.T) {
+	out, errOut, code := runCLI(t, "", "range", "--from", "2025-01-01T00:00Z", "--to", "2025-01-01T01:00Z")
+	if code != exitOK {
+		t.Fatalf("Expected exit 0, got %d: %s", code, errOut)
+	}
+
+	lo, _ := pulid.MinAt(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	hi, _ := pulid.MaxAt(time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC))
+	for _, want := range []string{lo.String(), hi.String(), lo.UUID(), hi.UUID(), lo.Encode(pulid.Hex), hi.Encode(pulid.Hex)} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("Range output should contain %s, got:\n%s", want, out)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"range"},
+		{"range", "--from", "2025-01-02", "--to", "2025-01-01"},
+		{"range", "--from", "2025-01-01", "--scope", "567"},
+	} {
+		if _, _, code = runCLI(t, "", args...); code != exitUsage {
+			t.Fatalf("Expected usage exit for %v, got %d", args, code)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+func runNew(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	var (
+		fs     = flag.NewFlagSet("new", flag.ContinueOnError)
+		scope  = fs.Uint("scope", uint(pulid.MaxScopeValue), "id scope")
+		count  = fs.Int("count", 1, "number of ids")
+		at     = fs.String("at", "", "RFC3339 timestamp, defaults to now")
+		format = fs.String("format", formatULID, "output format: ulid, uuid or hex")
+	)
+
+	fs.SetOutput(stderr)
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return exitUsage
+	}
+
+	if *scope > uint(pulid.MaxScopeValue) {
+		_, _ = fmt.Fprintf(stderr, "scope %d overflows %d\n", *scope, pulid.MaxScopeValue)
+		return exitUsage
+	}
+
+	if *count < 1 {
+		_, _ = fmt.Fprintf(stderr, "count %d must be at least 1\n", *count)
+		return exitUsage
+	}
+
+	if _, err := formatID(pulid.EmptyUID, *format); err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	ts := time.Now()
+	if *at != "" {
+		var err error
+		if ts, err = parseTime(*at); err != nil {
+			_, _ = fmt.Fprintf(stderr, "invalid --at: %v\n", err)
+			return exitUsage
+		}
+	}
+
+	out := bufio.NewWriter(stdout)
+	defer func() { _ = out.Flush() }()
+
+	for i := 0; i < *count; i++ {
+		id, err := pulid.NewScopedAt(ts, pulid.Scope(*scope))
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return exitError
+		}
+
+		s, _ := formatID(id, *format)
+		_, _ = fmt.Fprintln(out, s)
+	}
+
+	return exitOK
+}
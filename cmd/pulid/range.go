@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+func runRange(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	var (
+		fs   = flag.NewFlagSet("range", flag.ContinueOnError)
+		from = fs.String("from", "", "window start, RFC3339")
+		to   = fs.String("to", "", "window end, inclusive, RFC3339; defaults to now")
+	)
+
+	fs.SetOutput(stderr)
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return exitUsage
+	}
+
+	if *from == "" {
+		_, _ = fmt.Fprintln(stderr, "--from is required")
+		return exitUsage
+	}
+
+	start, err := parseTime(*from)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	end := time.Now()
+	if *to != "" {
+		if end, err = parseTime(*to); err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return exitUsage
+		}
+	}
+
+	if end.Before(start) {
+		_, _ = fmt.Fprintln(stderr, "--to is before --from")
+		return exitUsage
+	}
+
+	minID, err := pulid.MinAt(start)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	maxID, err := pulid.MaxAt(end)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "\tmin\tmax")
+	for _, format := range []string{formatULID, formatUUID, formatHex} {
+		lo, _ := formatID(minID, format)
+		hi, _ := formatID(maxID, format)
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", format, lo, hi)
+	}
+
+	if err = w.Flush(); err != nil {
+		return exitError
+	}
+
+	return exitOK
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pixie-sh/errors-go"
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+// maxLineSize longest NDJSON or plain line accepted
+const maxLineSize = 16 << 20
+
+// streamOptions selects where ids are read from on each input line.
+// With neither set every non empty line is an id
+type streamOptions struct {
+	// column CSV column, by header name or 1-based index; the first record is the header
+	column string
+	// field NDJSON top level string field
+	field string
+}
+
+// visitFn returns the replacement for an id value
+type visitFn func(line int, value string) (string, error)
+
+// reportFn receives the values visitFn or the input format rejected
+type reportFn func(line int, value string, err error)
+
+// stream reads in, calling visit for each id and writing the result to out, one output row per input row.
+// Rejected and blank plain lines are written as empty lines; rejected CSV and NDJSON values are written unchanged,
+// keeping the records intact. Empty CSV values and missing or null NDJSON fields are skipped.
+// Only read and write failures, and a missing CSV column, are returned
+func stream(in io.Reader, out io.Writer, opts streamOptions, visit visitFn, report reportFn) error {
+	switch {
+	case opts.column != "":
+		return streamCSV(in, out, opts.column, visit, report)
+	case opts.field != "":
+		return streamNDJSON(in, out, opts.field, visit, report)
+	default:
+		return streamLines(in, out, visit, report)
+	}
+}
+
+func streamLines(in io.Reader, out io.Writer, visit visitFn, report reportFn) error {
+	var (
+		w       = bufio.NewWriter(out)
+		scanner = bufio.NewScanner(in)
+		line    = 0
+	)
+
+	scanner.Buffer(make([]byte, 0, 64<<10), maxLineSize)
+	for scanner.Scan() {
+		line++
+
+		value := strings.TrimSpace(scanner.Text())
+		if value != "" {
+			converted, err := visit(line, value)
+			if err != nil {
+				report(line, value, err)
+			} else {
+				_, _ = w.WriteString(converted)
+			}
+		}
+
+		_ = w.WriteByte('\n')
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+func streamCSV(in io.Reader, out io.Writer, column string, visit visitFn, report reportFn) error {
+	var (
+		r = csv.NewReader(in)
+		w = csv.NewWriter(out)
+	)
+
+	r.FieldsPerRecord = -1
+	r.ReuseRecord = true
+
+	header, err := r.Read()
+	if err != nil {
+		return errors.Wrap(err, "unable to read the csv header")
+	}
+
+	idx := -1
+	if n, convErr := strconv.Atoi(column); convErr == nil {
+		idx = n - 1
+	} else {
+		for i, name := range header {
+			if name == column {
+				idx = i
+				break
+			}
+		}
+	}
+
+	if idx < 0 || idx >= len(header) {
+		return errors.New("csv column %q not found on header %v", column, header)
+	}
+
+	if err = w.Write(header); err != nil {
+		return err
+	}
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if idx < len(record) && record[idx] != "" {
+			line, _ := r.FieldPos(idx)
+
+			converted, visitErr := visit(line, record[idx])
+			if visitErr != nil {
+				report(line, record[idx], visitErr)
+			} else {
+				record[idx] = converted
+			}
+		}
+
+		if err = w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func streamNDJSON(in io.Reader, out io.Writer, field string, visit visitFn, report reportFn) error {
+	var (
+		w       = bufio.NewWriter(out)
+		scanner = bufio.NewScanner(in)
+		line    = 0
+	)
+
+	scanner.Buffer(make([]byte, 0, 64<<10), maxLineSize)
+	for scanner.Scan() {
+		line++
+
+		data := scanner.Bytes()
+		if len(bytes.TrimSpace(data)) > 0 {
+			converted, err := rewriteField(data, field, func(value string) (string, error) {
+				return visit(line, value)
+			})
+			if err != nil {
+				var value string
+				_ = json.Unmarshal(fieldValue(data, field), &value)
+				report(line, value, err)
+			} else {
+				data = converted
+			}
+		}
+
+		_, _ = w.Write(data)
+		_ = w.WriteByte('\n')
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+// rewriteField replaces the top level string field of the JSON object data,
+// keeping the remaining bytes, and so the keys order, untouched
+func rewriteField(data []byte, field string, fn func(string) (string, error)) ([]byte, error) {
+	start, end, err := locateField(data, field)
+	if err != nil || start < 0 {
+		return data, err
+	}
+
+	raw := data[start:end]
+	if bytes.Equal(raw, []byte("null")) {
+		return data, nil
+	}
+
+	var value string
+	if err = json.Unmarshal(raw, &value); err != nil {
+		return data, invalidJSON("field %q is not a string", field)
+	}
+
+	converted, err := fn(value)
+	if err != nil {
+		return data, err
+	}
+
+	quoted, _ := json.Marshal(converted)
+	out := make([]byte, 0, len(data)-len(raw)+len(quoted))
+	out = append(out, data[:start]...)
+	out = append(out, quoted...)
+	return append(out, data[end:]...), nil
+}
+
+// fieldValue returns the raw field value, nil if absent
+func fieldValue(data []byte, field string) []byte {
+	start, end, err := locateField(data, field)
+	if err != nil || start < 0 {
+		return nil
+	}
+
+	return data[start:end]
+}
+
+// locateField returns the raw value bounds of the top level field; start is -1 when absent
+func locateField(data []byte, field string) (int, int, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return -1, -1, invalidJSON("invalid json object")
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return -1, -1, invalidJSON("invalid json object").WithNestedError(err)
+		}
+
+		var raw json.RawMessage
+		if err = dec.Decode(&raw); err != nil {
+			return -1, -1, invalidJSON("invalid json object").WithNestedError(err)
+		}
+
+		if key == field {
+			end := int(dec.InputOffset())
+			return end - len(raw), end, nil
+		}
+	}
+
+	return -1, -1, nil
+}
+
+// invalidJSON reports NDJSON lines failing before the id is visited, with the pulid JSON error code
+func invalidJSON(format string, args ...interface{}) errors.E {
+	return errors.
+		New(format, args...).
+		WithErrorCode(pulid.InvalidJSONULIDSystemErrorCode).
+		WithNestedError(pulid.ErrInvalidJSON)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+func TestConvertCSV(t *testing.T) {
+	a, b := pulid.MustNew(), pulid.MustNew()
+	in := "name,id,note\nalice," + a.UUID() + ",x\nbob,,y\ncarol," + b.UUID() + ",\"with, comma\"\ndave,bad,z\n"
+
+	for _, column := range []string{"id", "2"} {
+		out, errOut, code := runCLI(t, in, "convert", "--to", "ulid", "--column", column)
+		want := "name,id,note\nalice," + a.String() + ",x\nbob,,y\ncarol," + b.String() + ",\"with, comma\"\ndave,bad,z\n"
+
+		if out != want {
+			t.Fatalf("Unexpected csv output for column %s:\n%s", column, out)
+		}
+
+		if code != exitError || !strings.Contains(errOut, "line 5: bad") {
+			t.Fatalf("Expected line 5 reported, got %d: %s", code, errOut)
+		}
+	}
+
+	if _, _, code := runCLI(t, in, "convert", "--column", "missing"); code != exitError {
+		t.Fatalf("Expected error for unknown column, got %d", code)
+	}
+}
+
+func TestConvertNDJSON(t *testing.T) {
+	a := pulid.MustNew()
+	in := `{"z":1,"id": "` + a.UUID() + `" ,"a":[1,2]}` + "\n" + `{"id":null}` + "\n" + `{"other":"x"}` + "\n" + `{"id":"bad"}` + "\n" + "not json\n"
+
+	out, errOut, code := runCLI(t, in, "convert", "--to", "ulid", "--field", "id")
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+
+	if len(lines) != 5 || lines[0] != `{"z":1,"id": "`+a.String()+`" ,"a":[1,2]}` || lines[1] != `{"id":null}` || lines[3] != `{"id":"bad"}` {
+		t.Fatalf("Unexpected ndjson output:\n%s", out)
+	}
+
+	if code != exitError || !strings.Contains(errOut, "line 4: bad") || !strings.Contains(errOut, "line 5") {
+		t.Fatalf("Expected lines 4 and 5 reported, got %d: %s", code, errOut)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ids.txt")
+	content := pulid.MustNew().String() + "\n" + "01JJN1AD5B08VJ5SRBJAWCBWD!\n" + pulid.MustNew().UUID() + "\n" + "short\n"
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write input: %v", err)
+	}
+
+	out, errOut, code := runCLI(t, "", "validate", path)
+	if code != exitError {
+		t.Fatalf("Expected exit 1, got %d", code)
+	}
+
+	if !strings.Contains(out, path+":2:") || !strings.Contains(out, "InvalidCharsULIDSystemErrorCode") ||
+		!strings.Contains(out, path+":4:") || !strings.Contains(out, "InvalidSizeULIDSystemErrorCode") {
+		t.Fatalf("Unexpected report:\n%s", out)
+	}
+
+	if !strings.Contains(errOut, "4 ids checked, 2 invalid") {
+		t.Fatalf("Unexpected summary: %s", errOut)
+	}
+
+	if out, _, code = runCLI(t, `{"id":"`+pulid.MustNew().String()+`"}`+"\n", "validate", "--field", "id"); code != exitOK || out != "" {
+		t.Fatalf("Expected valid ndjson to pass, got %d: %s", code, out)
+	}
+
+	out, errOut, code = runCLI(t, `{"id":"bad"}`+"\n"+"not json\n", "validate", "--field", "id")
+	if code != exitError || !strings.Contains(errOut, "2 ids checked, 2 invalid") {
+		t.Fatalf("Expected malformed lines counted as checked, got %d: %s", code, errOut)
+	}
+
+	if !strings.Contains(out, "stdin:2:") || !strings.Contains(out, "InvalidJSONULIDSystemErrorCode") {
+		t.Fatalf("Expected the malformed line reported with the json error code, got:\n%s", out)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pixie-sh/errors-go"
+)
+
+func runValidate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var (
+		fs     = flag.NewFlagSet("validate", flag.ContinueOnError)
+		column = fs.String("column", "", "validate the CSV column, by header name or 1-based index")
+		field  = fs.String("field", "", "validate the NDJSON top level field")
+	)
+
+	fs.SetOutput(stderr)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if *column != "" && *field != "" {
+		_, _ = fmt.Fprintln(stderr, "--column and --field are mutually exclusive")
+		return exitUsage
+	}
+
+	var (
+		opts    = streamOptions{column: *column, field: *field}
+		valid   = 0
+		invalid = 0
+	)
+
+	validate := func(name string, in io.Reader) error {
+		visit := func(_ int, value string) (string, error) {
+			if _, err := parseID(value); err != nil {
+				return value, err
+			}
+
+			valid++
+			return value, nil
+		}
+
+		report := func(line int, value string, err error) {
+			invalid++
+			_, _ = fmt.Fprintf(stdout, "%s:%d: %q: %s\n", name, line, value, describe(err))
+		}
+
+		return stream(in, io.Discard, opts, visit, report)
+	}
+
+	if fs.NArg() == 0 {
+		if err := validate("stdin", stdin); err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return exitError
+		}
+	}
+
+	for _, name := range fs.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return exitError
+		}
+
+		err = validate(name, f)
+		_ = f.Close()
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "%s: %v\n", name, err)
+			return exitError
+		}
+	}
+
+	// invalid also counts lines rejected before reaching visit, e.g. malformed NDJSON
+	_, _ = fmt.Fprintf(stderr, "%d ids checked, %d invalid\n", valid+invalid, invalid)
+	if invalid > 0 {
+		return exitError
+	}
+
+	return exitOK
+}
+
+// describe formats err with its errors-go code, if any
+func describe(err error) string {
+	if e, ok := errors.As(err); ok && e.Code.Name != "" {
+		return fmt.Sprintf("%s (%d): %s", e.Code.Name, e.Code.Value, e.Message)
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,135 @@
+package pulid
+
+import (
+	"bytes"
+	"encoding/hex"
+	goErrors "errors"
+	mathRand "math/rand"
+	"strings"
+	"testing"
+
+	"github.com/oklog/ulid"
+)
+
+// specVectors ULID text forms with their binary value and 48 bits epoch
+var specVectors = []struct {
+	text  string
+	hex   string
+	epoch uint64
+}{
+	{"00000000000000000000000000", "00000000000000000000000000000000", 0},
+	{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", "ffffffffffffffffffffffffffffffff", 281474976710655},
+	{"7ZZZZZZZZZ0000000000000000", "ffffffffffff00000000000000000000", 281474976710655},
+	{"0000000001ZZZZZZZZZZZZZZZZ", "000000000001ffffffffffffffffffff", 1},
+	{"01ARZ3NDEKTSV4RRFFQ69G5FAV", "01563e3ab5d3d6764c61efb99302bd5b", 1469922850259},
+	{"01BX5ZZKBKACTAV9WEVGEMMVRZ", "015f4bffcd735334ada78edc1d4a6f1f", 1508808576371},
+}
+
+func TestSpecVectors(t *testing.T) {
+	for _, v := range specVectors {
+		raw, _ := hex.DecodeString(v.hex)
+
+		var id ULID
+		copy(id[:], raw)
+
+		if got := id.String(); got != v.text {
+			t.Fatalf("encode %s: expected %s, got %s", v.hex, v.text, got)
+		}
+
+		parsed, err := UnmarshalString(v.text)
+		if err != nil {
+			t.Fatalf("decode %s: %v", v.text, err)
+		}
+		if parsed != id {
+			t.Fatalf("decode %s: expected %x, got %x", v.text, id[:], parsed[:])
+		}
+
+		lower, err := UnmarshalString(strings.ToLower(v.text))
+		if err != nil || lower != id {
+			t.Fatalf("decode lowercase %s: %x, %v", v.text, lower[:], err)
+		}
+
+		if got := id.Epoch(); got != v.epoch {
+			t.Fatalf("epoch %s: expected %d, got %d", v.text, v.epoch, got)
+		}
+	}
+}
+
+func TestOklogEncodeDifferential(t *testing.T) {
+	rnd := mathRand.New(mathRand.NewSource(1))
+
+	ids := []ULID{EmptyUID, {0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}}
+	for i := 0; i < 10000; i++ {
+		var id ULID
+		_, _ = rnd.Read(id[:])
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids {
+		expected := ulid.ULID(id).String()
+		if got := id.String(); got != expected {
+			t.Fatalf("encode %x: oklog %s, pulid %s", id[:], expected, got)
+		}
+
+		text, err := id.MarshalText()
+		if err != nil || string(text) != expected {
+			t.Fatalf("marshal %x: oklog %s, pulid %s, %v", id[:], expected, text, err)
+		}
+
+		parsed, err := UnmarshalString(expected)
+		if err != nil || parsed != id {
+			t.Fatalf("decode %s: expected %x, got %x, %v", expected, id[:], parsed[:], err)
+		}
+	}
+}
+
+func TestOklogDecodeDifferential(t *testing.T) {
+	const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!-_ "
+
+	rnd := mathRand.New(mathRand.NewSource(1))
+
+	inputs := []string{
+		"00000000000000000000000000",
+		"7ZZZZZZZZZZZZZZZZZZZZZZZZZ",
+		"7zzzzzzzzzzzzzzzzzzzzzzzzz",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAI",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAL",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAO",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU",
+	}
+
+	// every first char above '7' overflows 128 bits
+	for _, c := range encoding[8:] {
+		inputs = append(inputs, string(c)+"0000000000000000000000000", string(c)+"ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	}
+
+	for i := 0; i < 10000; i++ {
+		var b [textEncodedSize]byte
+		for j := range b {
+			if rnd.Intn(8) == 0 {
+				b[j] = alphabet[rnd.Intn(len(alphabet))]
+			} else {
+				b[j] = encoding[rnd.Intn(len(encoding))]
+			}
+		}
+		inputs = append(inputs, string(b[:]))
+	}
+
+	for _, s := range inputs {
+		expected, oErr := ulid.ParseStrict(s)
+		got, err := UnmarshalString(s)
+
+		switch {
+		case oErr == nil && err != nil:
+			t.Fatalf("decode %q: oklog accepted, pulid failed: %v", s, err)
+		case oErr != nil && err == nil:
+			t.Fatalf("decode %q: pulid accepted, oklog failed: %v", s, oErr)
+		case oErr == ulid.ErrOverflow && !goErrors.Is(err, ErrInvalidSize):
+			t.Fatalf("decode %q: oklog overflow, pulid %v", s, err)
+		case oErr == ulid.ErrInvalidCharacters && !goErrors.Is(err, ErrInvalidChars):
+			t.Fatalf("decode %q: oklog invalid characters, pulid %v", s, err)
+		case oErr == nil && !bytes.Equal(expected[:], got[:]):
+			t.Fatalf("decode %q: oklog %x, pulid %x", s, expected[:], got[:])
+		}
+	}
+}
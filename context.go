@@ -0,0 +1,20 @@
+package pulid
+
+import "context"
+
+// ContextKey typed key for ULIDs stored on a context.Context
+type ContextKey string
+
+// RequestIDKey context key for the request/correlation id
+const RequestIDKey ContextKey = "pulid.request_id"
+
+// WithID returns a copy of ctx holding id under key
+func WithID(ctx context.Context, key ContextKey, id ULID) context.Context {
+	return context.WithValue(ctx, key, id)
+}
+
+// FromContext returns the id stored under key, if any
+func FromContext(ctx context.Context, key ContextKey) (ULID, bool) {
+	id, ok := ctx.Value(key).(ULID)
+	return id, ok
+}
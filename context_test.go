@@ -0,0 +1,24 @@
+package pulid
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContextID(t *testing.T) {
+	id := MustNew()
+	ctx := WithID(context.Background(), RequestIDKey, id)
+
+	got, ok := FromContext(ctx, RequestIDKey)
+	if !ok || got != id {
+		t.Fatalf("Expected %v from context, got %v (%t)", id, got, ok)
+	}
+
+	if _, ok = FromContext(ctx, ContextKey("other")); ok {
+		t.Fatalf("Unexpected id under another key")
+	}
+
+	if _, ok = FromContext(context.WithValue(context.Background(), "pulid.request_id", id), RequestIDKey); ok {
+		t.Fatalf("Untyped string keys must not collide with RequestIDKey")
+	}
+}
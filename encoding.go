@@ -0,0 +1,234 @@
+package pulid
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"math/bits"
+
+	"github.com/pixie-sh/errors-go"
+)
+
+// Encoding converts ULIDs to and from a fixed length text form
+type Encoding interface {
+	// Name returns the encoding name, e.g. "base58"
+	Name() string
+	// EncodedLen returns the fixed length of the encoded form
+	EncodedLen() int
+	// OrderPreserving reports whether encoded strings sort lexically as the binary ids
+	OrderPreserving() bool
+	// AppendEncode appends the encoded id to dst
+	AppendEncode(dst []byte, id ULID) []byte
+	// Decode parses src, which must be EncodedLen long
+	Decode(src []byte) (ULID, error)
+}
+
+var (
+	// Base32Crockford the ULID text form, 26 chars; order preserving
+	Base32Crockford Encoding = crockfordEncoding{}
+	// Base58 bitcoin alphabet, 22 chars left padded with '1'; order preserving, as the alphabet is ASCII sorted
+	Base58 Encoding = newBaseNEncoding("base58", "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+	// Base62 0-9A-Za-z alphabet, 22 chars left padded with '0'; order preserving, as the alphabet is ASCII sorted
+	Base62 Encoding = newBaseNEncoding("base62", "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+	// Base64URL RFC 4648 URL safe alphabet without padding, 22 chars; NOT order preserving
+	Base64URL Encoding = base64URLEncoding{}
+	// Hex lowercase hexadecimal, 32 chars, the hyphenless UUID; order preserving. Decoding accepts uppercase
+	Hex Encoding = hexEncoding{}
+)
+
+// Encode returns the id encoded with enc
+func (id ULID) Encode(enc Encoding) string {
+	return string(enc.AppendEncode(make([]byte, 0, enc.EncodedLen()), id))
+}
+
+// Decode parses s encoded with enc
+func Decode(s string, enc Encoding) (ULID, error) {
+	return enc.Decode([]byte(s))
+}
+
+type crockfordEncoding struct{}
+
+func (crockfordEncoding) Name() string          { return "base32crockford" }
+func (crockfordEncoding) EncodedLen() int       { return textEncodedSize }
+func (crockfordEncoding) OrderPreserving() bool { return true }
+
+func (crockfordEncoding) AppendEncode(dst []byte, id ULID) []byte {
+	dst, _ = id.AppendText(dst)
+	return dst
+}
+
+func (crockfordEncoding) Decode(src []byte) (ULID, error) {
+	var id ULID
+
+	// UnmarshalText also accepts UUIDs
+	if len(src) != textEncodedSize {
+		return EmptyUID, invalidLengthError(parseExpectedULID, src, textEncodedSize)
+	}
+
+	if err := id.UnmarshalText(src); err != nil {
+		return EmptyUID, err
+	}
+
+	return id, nil
+}
+
+type hexEncoding struct{}
+
+func (hexEncoding) Name() string          { return "hex" }
+func (hexEncoding) EncodedLen() int       { return hex.EncodedLen(ulid16Bytes) }
+func (hexEncoding) OrderPreserving() bool { return true }
+
+func (hexEncoding) AppendEncode(dst []byte, id ULID) []byte {
+	return hex.AppendEncode(dst, id[:])
+}
+
+func (e hexEncoding) Decode(src []byte) (ULID, error) {
+	var id ULID
+
+	if len(src) != e.EncodedLen() {
+		return EmptyUID, invalidLengthError(e.Name(), src, e.EncodedLen())
+	}
+
+	for i, c := range src {
+		if !isHex(c) {
+			return EmptyUID, invalidCharError(e.Name(), src, i)
+		}
+	}
+
+	_, _ = hex.Decode(id[:], src)
+
+	return id, nil
+}
+
+type base64URLEncoding struct{}
+
+func (base64URLEncoding) Name() string          { return "base64url" }
+func (base64URLEncoding) EncodedLen() int       { return base64.RawURLEncoding.EncodedLen(ulid16Bytes) }
+func (base64URLEncoding) OrderPreserving() bool { return false }
+
+func (base64URLEncoding) AppendEncode(dst []byte, id ULID) []byte {
+	return base64.RawURLEncoding.AppendEncode(dst, id[:])
+}
+
+func (e base64URLEncoding) Decode(src []byte) (ULID, error) {
+	var id ULID
+
+	if len(src) != e.EncodedLen() {
+		return EmptyUID, invalidLengthError(e.Name(), src, e.EncodedLen())
+	}
+
+	// the std decoder skips '\r' and '\n'; reject anything outside the alphabet upfront
+	for i, c := range src {
+		if !isBase64URL(c) {
+			return EmptyUID, invalidCharError(e.Name(), src, i)
+		}
+	}
+
+	// strict rejects non zero trailing bits, keeping a single encoding per id
+	if _, err := base64.RawURLEncoding.Strict().Decode(id[:], src); err != nil {
+		if offset, ok := err.(base64.CorruptInputError); ok && int(offset) < len(src) {
+			return EmptyUID, invalidCharError(e.Name(), src, int(offset))
+		}
+
+		return EmptyUID, errors.
+			New("invalid characters").
+			WithErrorCode(InvalidCharsULIDSystemErrorCode).
+			WithNestedError(ErrInvalidChars, err)
+	}
+
+	return id, nil
+}
+
+func isBase64URL(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '-' || c == '_'
+}
+
+// baseNEncoding encodes the 128 bits id as a fixed length big-endian number on the alphabet base
+type baseNEncoding struct {
+	name     string
+	alphabet string
+	base     uint64
+	size     int
+	decode   [256]byte
+}
+
+func newBaseNEncoding(name, alphabet string) *baseNEncoding {
+	e := &baseNEncoding{
+		name:     name,
+		alphabet: alphabet,
+		base:     uint64(len(alphabet)),
+	}
+
+	// smallest size where base^size covers 2^128
+	e.size = int(math.Ceil(ulidBits / math.Log2(float64(e.base))))
+
+	for i := range e.decode {
+		e.decode[i] = 0xFF
+	}
+	for i := 0; i < len(alphabet); i++ {
+		e.decode[alphabet[i]] = byte(i)
+	}
+
+	return e
+}
+
+func (e *baseNEncoding) Name() string          { return e.name }
+func (e *baseNEncoding) EncodedLen() int       { return e.size }
+func (e *baseNEncoding) OrderPreserving() bool { return true }
+
+// AppendEncode always runs size divisions, regardless of the id value
+func (e *baseNEncoding) AppendEncode(dst []byte, id ULID) []byte {
+	var (
+		hi  = binary.BigEndian.Uint64(id[:8])
+		lo  = binary.BigEndian.Uint64(id[8:])
+		n   = len(dst)
+		rem uint64
+	)
+
+	dst = append(dst, make([]byte, e.size)...)
+	for i := n + e.size - 1; i >= n; i-- {
+		hi, rem = bits.Div64(0, hi, e.base)
+		lo, rem = bits.Div64(rem, lo, e.base)
+		dst[i] = e.alphabet[rem]
+	}
+
+	return dst
+}
+
+func (e *baseNEncoding) Decode(src []byte) (ULID, error) {
+	var (
+		id       ULID
+		hi, lo   uint64
+		overflow uint64
+	)
+
+	if len(src) != e.size {
+		return EmptyUID, invalidLengthError(e.name, src, e.size)
+	}
+
+	for i, c := range src {
+		d := e.decode[c]
+		if d == 0xFF {
+			return EmptyUID, invalidCharError(e.name, src, i)
+		}
+
+		var carry, hiCarry uint64
+		carry, lo = bits.Mul64(lo, e.base)
+		hiCarry, hi = bits.Mul64(hi, e.base)
+		overflow |= hiCarry
+
+		var c1, c2 uint64
+		lo, c1 = bits.Add64(lo, uint64(d), 0)
+		hi, c2 = bits.Add64(hi, carry, c1)
+		overflow |= c2
+	}
+
+	if overflow != 0 {
+		return EmptyUID, overflowError(e.name, src)
+	}
+
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id, nil
+}
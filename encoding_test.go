@@ -0,0 +1,91 @@
+package pulid
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var encodings = []Encoding{Base32Crockford, Base58, Base62, Base64URL, Hex}
+
+func TestEncodingsRoundTrip(t *testing.T) {
+	boundaries := []ULID{
+		EmptyUID,
+		{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+		{15: 1},
+	}
+
+	for _, enc := range encodings {
+		ids := append([]ULID{}, boundaries...)
+		for i := 0; i < 1000; i++ {
+			ids = append(ids, MustNewScoped(Scope(i)))
+		}
+
+		for _, id := range ids {
+			s := id.Encode(enc)
+			if len(s) != enc.EncodedLen() {
+				t.Fatalf("%s length mismatch: expected %d got %d (%s)", enc.Name(), enc.EncodedLen(), len(s), s)
+			}
+
+			decoded, err := Decode(s, enc)
+			if err != nil {
+				t.Fatalf("%s decoding of %s failed: %v", enc.Name(), s, err)
+			}
+
+			if decoded != id {
+				t.Fatalf("%s round trip failed: expected %v, got %v", enc.Name(), id, decoded)
+			}
+		}
+	}
+
+	if Base32Crockford.EncodedLen() != 26 || Base58.EncodedLen() != 22 || Base62.EncodedLen() != 22 ||
+		Base64URL.EncodedLen() != 22 || Hex.EncodedLen() != 32 {
+		t.Fatalf("Unexpected encoded lengths")
+	}
+
+	if MustNew().Encode(Base32Crockford) == "" || EmptyUID.Encode(Base62) != strings.Repeat("0", 22) {
+		t.Fatalf("Unexpected encoding output")
+	}
+}
+
+func TestEncodingsLexicalOrder(t *testing.T) {
+	ids := make([]ULID, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		var id ULID
+		_, _ = defaultEntropy.Read(id[:])
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i][:], ids[j][:]) < 0 })
+
+	for _, enc := range encodings {
+		if !enc.OrderPreserving() {
+			continue
+		}
+
+		for i := 1; i < len(ids); i++ {
+			if ids[i-1].Encode(enc) >= ids[i].Encode(enc) {
+				t.Fatalf("%s does not preserve order: %v >= %v", enc.Name(), ids[i-1], ids[i])
+			}
+		}
+	}
+}
+
+func TestEncodingsErrors(t *testing.T) {
+	invalid := map[Encoding][]string{
+		Base32Crockford: {MustNew().UUID(), "01JJN1AD5B08VJ5SRBJAWCBWD"},
+		Base58:          {"0000000000000000000000", "zzzzzzzzzzzzzzzzzzzzzz", "1111"},
+		Base62:          {"zzzzzzzzzzzzzzzzzzzzzz", "000000000000000000000-"},
+		Base64URL:       {"AAAAAAAAAAAAAAAAAAAAA+", "AAAAAAAAAAAAAAAAAAAAAB"},
+		Hex:             {"0194aa1534ab023722e70b92b8c5f1bg", "0194aa15"},
+	}
+
+	for enc, inputs := range invalid {
+		for _, s := range inputs {
+			if _, err := Decode(s, enc); err == nil {
+				t.Fatalf("Expected %s error decoding %s", enc.Name(), s)
+			}
+		}
+	}
+}
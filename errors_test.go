@@ -0,0 +1,141 @@
+package pulid
+
+import (
+	goErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/pixie-sh/errors-go"
+)
+
+func TestErrorCodesAreDistinct(t *testing.T) {
+	codes := []errors.ErrorCode{
+		InvalidSizeULIDSystemErrorCode,
+		InvalidTimeFormatULIDSystemErrorCode,
+		InvalidCharsULIDSystemErrorCode,
+		InvalidScopeULIDSystemErrorCode,
+		InvalidNodeULIDSystemErrorCode,
+		InvalidLayoutULIDSystemErrorCode,
+		InvalidJSONULIDSystemErrorCode,
+	}
+
+	seen := map[int]string{}
+	for _, code := range codes {
+		if other, ok := seen[code.Value]; ok {
+			t.Fatalf("%s shares the value %d with %s", code.Name, code.Value, other)
+		}
+		seen[code.Value] = code.Name
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	_, uuidErr := UnmarshalUUID("0194aa15-34ab-0237-22e7-0b92b8c5f1bZ")
+	_, jsonErr := UnmarshalString("")
+	var jsonID ULID
+
+	cases := []struct {
+		err      error
+		sentinel error
+		code     errors.ErrorCode
+	}{
+		{unmarshalStringErr("01JJN1AD5B08VJ5SRBJAWCBWD"), ErrInvalidSize, InvalidSizeULIDSystemErrorCode},
+		{unmarshalStringErr("01JJN1AD5B08VJ5SRBJAWCBWDU"), ErrInvalidChars, InvalidCharsULIDSystemErrorCode},
+		{unmarshalStringErr("81JJN1AD5B08VJ5SRBJAWCBWDQ"), ErrInvalidSize, InvalidSizeULIDSystemErrorCode},
+		{uuidErr, ErrInvalidChars, InvalidCharsULIDSystemErrorCode},
+		{jsonErr, ErrInvalidSize, InvalidSizeULIDSystemErrorCode},
+		{jsonID.UnmarshalJSON([]byte("123")), ErrInvalidJSON, InvalidJSONULIDSystemErrorCode},
+		{errOf(NewScopedAt(time.Unix(-1, 0), 1)), ErrInvalidTimeFormat, InvalidTimeFormatULIDSystemErrorCode},
+		{errOf(Layout{EpochBits: 48}.MinAt(time.Now())), ErrInvalidLayout, InvalidLayoutULIDSystemErrorCode},
+		{errOf(MustNew().Node(DefaultLayout)), ErrInvalidNode, InvalidNodeULIDSystemErrorCode},
+	}
+
+	for i, c := range cases {
+		if !goErrors.Is(c.err, c.sentinel) {
+			t.Fatalf("Case %d: expected errors.Is(%v, %v)", i, c.err, c.sentinel)
+		}
+
+		if _, ok := errors.Has(c.err, c.code); !ok {
+			t.Fatalf("Case %d: expected code %s on %v", i, c.code.Name, c.err)
+		}
+	}
+}
+
+func TestInvalidCharFields(t *testing.T) {
+	cases := map[string]string{
+		"01JJN1AD5B08VJ5SRBJAWCBWDU":           "25",
+		"01JJN1AD5B08VJ5SRBjaw!BWDQ":           "21",
+		"0194aa15-34ab-0237-22e7-0b92b8c5f1bZ": "35",
+		"0194ag15-34ab-0237-22e7-0b92b8c5f1b7": "5",
+	}
+
+	for input, index := range cases {
+		e, ok := errors.As(unmarshalStringErr(input))
+		if !ok || len(e.FieldErrors) != 1 {
+			t.Fatalf("Expected a field error for %s, got %+v", input, e)
+		}
+
+		if field := e.FieldErrors[0]; field.Param != index || field.Rule != "charset" {
+			t.Fatalf("Expected invalid character at index %s for %s, got %+v", index, input, field)
+		}
+	}
+}
+
+func unmarshalStringErr(s string) error {
+	_, err := UnmarshalString(s)
+	return err
+}
+
+func errOf[T any](_ T, err error) error {
+	return err
+}
+
+func TestParseError(t *testing.T) {
+	cases := []struct {
+		input    string
+		decode   func(string) error
+		expected string
+		index    int
+		char     byte
+		reason   string
+		sentinel error
+	}{
+		{"01JJN1AD5B08VJ5SRBJAWCBWDU", unmarshalStringErr, "ULID", 25, 'U', "charset", ErrInvalidChars},
+		{"01JJN1AD5B08VJ5SRBJAWCBWD", unmarshalStringErr, "ULID", 25, 0, "length", ErrInvalidSize},
+		{"01JJN1AD5B08VJ5SRBJAWCBWDQQ", unmarshalStringErr, "ULID", 26, 'Q', "length", ErrInvalidSize},
+		{"81JJN1AD5B08VJ5SRBJAWCBWDQ", unmarshalStringErr, "ULID", 0, '8', "overflow", ErrInvalidSize},
+		{"0194aa15-34ab-0237-22e7-0b92b8c5f1bZ", unmarshalStringErr, "UUID", 35, 'Z', "charset", ErrInvalidChars},
+		{"0194aa1534ab023722e70b92b8c5f1bg", decodeErr(Hex), "hex", 31, 'g', "charset", ErrInvalidChars},
+		{"0000000000000000000000", decodeErr(Base58), "base58", 0, '0', "charset", ErrInvalidChars},
+		{"zzzzzzzzzzzzzzzzzzzzzz", decodeErr(Base62), "base62", 0, 'z', "overflow", ErrInvalidSize},
+		{"AZSqFTSrAjci5wuSuMXxt!", decodeErr(Base64URL), "base64url", 21, '!', "charset", ErrInvalidChars},
+	}
+
+	for _, c := range cases {
+		err := c.decode(c.input)
+
+		var pe *ParseError
+		if !goErrors.As(err, &pe) {
+			t.Fatalf("Expected ParseError for %s, got %v", c.input, err)
+		}
+
+		if pe.Input != c.input || pe.Expected != c.expected || pe.Index != c.index || pe.Char != c.char || pe.Reason != c.reason {
+			t.Fatalf("Unexpected ParseError for %s: %+v", c.input, pe)
+		}
+
+		if !goErrors.Is(err, c.sentinel) {
+			t.Fatalf("Expected %v to match %v", err, c.sentinel)
+		}
+
+		e, _ := errors.As(err)
+		if len(e.FieldErrors) != 1 || e.FieldErrors[0].Field != c.expected || e.FieldErrors[0].Rule != c.reason {
+			t.Fatalf("Unexpected field errors for %s: %+v", c.input, e.FieldErrors)
+		}
+	}
+}
+
+func decodeErr(enc Encoding) func(string) error {
+	return func(s string) error {
+		_, err := Decode(s, enc)
+		return err
+	}
+}
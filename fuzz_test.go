@@ -0,0 +1,223 @@
+package pulid
+
+import (
+	"bytes"
+	"encoding/json"
+	goErrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/pixie-sh/errors-go"
+)
+
+// fuzzSeedIDs boundary and generated ids shared by the fuzz targets seeds
+func fuzzSeedIDs() []ULID {
+	return []ULID{
+		EmptyUID,
+		{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+		{0x01, 0x94, 0xaa, 0x15, 0x34, 0xab, 0x02, 0x37, 0x22, 0xe7, 0x0b, 0x92, 0xb8, 0xc5, 0xf1, 0xb7},
+		MustNewScoped(567),
+	}
+}
+
+func FuzzUnmarshalText(f *testing.F) {
+	for _, id := range fuzzSeedIDs() {
+		f.Add(id.String())
+		f.Add(id.EncodeLower())
+		f.Add(id.UUID())
+	}
+	f.Add("81JJN1AD5B08VJ5SRBJAWCBWDQ")
+	f.Add("01JJN1AD5B08VJ5SRBJAWCBWDU")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		var id ULID
+		err := id.UnmarshalText([]byte(s))
+		if err != nil {
+			var pe *ParseError
+			if !goErrors.As(err, &pe) || pe.Input != s || pe.Index < 0 || pe.Index > len(s) {
+				t.Fatalf("UnmarshalText %q: expected ParseError within the input, got %+v", s, err)
+			}
+
+			if pe.Index < len(s) && pe.Char != s[pe.Index] {
+				t.Fatalf("UnmarshalText %q: ParseError char %q does not match index %d", s, pe.Char, pe.Index)
+			}
+			return
+		}
+
+		// text is canonical once upper cased: a single text form per id
+		if len(s) == textEncodedSize && id.String() != strings.ToUpper(s) {
+			t.Fatalf("UnmarshalText %q: re-encoded as %s", s, id.String())
+		}
+
+		if len(s) == uuidStringLength && id.UUID() != strings.ToLower(s) {
+			t.Fatalf("UnmarshalText %q: re-encoded as %s", s, id.UUID())
+		}
+	})
+}
+
+// FuzzRoundTrip checks binary, text, lowercase text, UUID, hex, JSON and the
+// remaining encodings all decode back to the same id
+func FuzzRoundTrip(f *testing.F) {
+	for _, id := range fuzzSeedIDs() {
+		f.Add(id[:])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		id, err := UnmarshalBytes(data)
+		if len(data) != ulid16Bytes {
+			if err == nil || !goErrors.Is(err, ErrInvalidSize) {
+				t.Fatalf("UnmarshalBytes of %d bytes should fail with ErrInvalidSize, got %v", len(data), err)
+			}
+			return
+		}
+
+		if err != nil {
+			t.Fatalf("UnmarshalBytes failed: %v", err)
+		}
+
+		if raw, _ := id.MarshalBinary(); !bytes.Equal(raw, data) {
+			t.Fatalf("Binary round trip: expected %x got %x", data, raw)
+		}
+
+		for _, s := range []string{id.String(), id.EncodeLower(), id.UUID()} {
+			parsed, err := UnmarshalString(s)
+			if err != nil || parsed != id {
+				t.Fatalf("Text round trip of %x through %s: got %x; err %+v", data, s, parsed, err)
+			}
+		}
+
+		if parsed, err := UnmarshalUUID(id.UUID()); err != nil || parsed != id {
+			t.Fatalf("UUID round trip of %x: got %x; err %+v", data, parsed, err)
+		}
+
+		for _, enc := range []Encoding{Base32Crockford, Base58, Base62, Base64URL, Hex} {
+			encoded := id.Encode(enc)
+			if len(encoded) != enc.EncodedLen() {
+				t.Fatalf("%s encoded %x to %d chars, expected %d", enc.Name(), data, len(encoded), enc.EncodedLen())
+			}
+
+			if parsed, err := Decode(encoded, enc); err != nil || parsed != id {
+				t.Fatalf("%s round trip of %x: got %x; err %+v", enc.Name(), data, parsed, err)
+			}
+		}
+
+		for _, format := range []JSONFormat{JSONFormatULID, JSONFormatUUID, JSONFormatObject} {
+			blob, err := id.AppendJSON(nil, format)
+			if err != nil {
+				t.Fatalf("AppendJSON failed: %v", err)
+			}
+
+			var parsed ULID
+			if err = json.Unmarshal(blob, &parsed); err != nil || parsed != id {
+				t.Fatalf("JSON round trip of %x through %s: got %x; err %+v", data, blob, parsed, err)
+			}
+		}
+
+		var scanned ULID
+		if err = scanned.Scan(id.UUID()); err != nil || scanned != id {
+			t.Fatalf("Scan round trip of %x: got %x; err %+v", data, scanned, err)
+		}
+	})
+}
+
+func FuzzUint64RoundTrip(f *testing.F) {
+	f.Add(uint64(0))
+	f.Add(uint64(1) << 63)
+	f.Add(^uint64(0))
+
+	f.Fuzz(func(t *testing.T, n uint64) {
+		id, err := UnmarshalUint64(n)
+		if err != nil {
+			t.Fatalf("UnmarshalUint64(%d) failed: %v", n, err)
+		}
+
+		got, err := id.MarshallUint64()
+		if err != nil || got != n {
+			t.Fatalf("Uint64 round trip of %d: got %d; err %+v", n, got, err)
+		}
+
+		if parsed, err := UnmarshalString(id.String()); err != nil || parsed != id {
+			t.Fatalf("Text round trip of uint64 id %x: got %x; err %+v", id[:], parsed, err)
+		}
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	encodings := []Encoding{Base32Crockford, Base58, Base62, Base64URL, Hex}
+	for _, id := range fuzzSeedIDs() {
+		for i, enc := range encodings {
+			f.Add(uint8(i), id.Encode(enc))
+		}
+	}
+	f.Add(uint8(2), "zzzzzzzzzzzzzzzzzzzzzz")
+
+	f.Fuzz(func(t *testing.T, i uint8, s string) {
+		enc := encodings[int(i)%len(encodings)]
+
+		id, err := Decode(s, enc)
+		if err != nil {
+			var pe *ParseError
+			if !goErrors.As(err, &pe) || pe.Index < 0 || pe.Index > len(s) {
+				t.Fatalf("%s decode %q: expected ParseError within the input, got %+v", enc.Name(), s, err)
+			}
+			return
+		}
+
+		// every accepted input is the single encoding of the id, modulo case for case insensitive alphabets
+		encoded := id.Encode(enc)
+		if encoded != s && !strings.EqualFold(encoded, s) {
+			t.Fatalf("%s decode %q: re-encoded as %s", enc.Name(), s, encoded)
+		}
+	})
+}
+
+func FuzzUnmarshalJSON(f *testing.F) {
+	for _, id := range fuzzSeedIDs() {
+		for _, format := range []JSONFormat{JSONFormatULID, JSONFormatUUID, JSONFormatObject} {
+			blob, _ := id.AppendJSON(nil, format)
+			f.Add(blob)
+		}
+	}
+	f.Add([]byte(`null`))
+	f.Add([]byte(`"usr_01JJN1AD5B08VJ5SRBJAWCBWDQ"`))
+	f.Add([]byte(`{"id":"01JJN1AD5B08VJ5SRBJAWCBWDQ"}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var id ULID
+		if err := id.UnmarshalJSON(data); err != nil {
+			if e, ok := errors.As(err); !ok || e.Code.Value == 0 {
+				t.Fatalf("UnmarshalJSON %q: expected a coded error, got %v", data, err)
+			}
+			return
+		}
+
+		blob, _ := id.AppendJSON(nil, JSONFormatULID)
+		var again ULID
+		if err := again.UnmarshalJSON(blob); err != nil || again != id {
+			t.Fatalf("UnmarshalJSON %q: round trip got %x; err %+v", data, again, err)
+		}
+	})
+}
+
+func FuzzScopeSchema(f *testing.F) {
+	f.Add(uint16(567))
+	f.Add(uint16(1))
+	f.Add(MaxScopeValue)
+
+	f.Fuzz(func(t *testing.T, scope uint16) {
+		values := entityRegionSchema.Unpack(scope)
+
+		packed, err := entityRegionSchema.Pack(map[string]uint16(values))
+		if scope == ZeroedScopeValue || scope == MaxScopeValue {
+			if err == nil {
+				t.Fatalf("Reserved scope %d should not pack", scope)
+			}
+			return
+		}
+
+		if err != nil || packed != scope {
+			t.Fatalf("Scope schema round trip of %d: got %d; err %+v", scope, packed, err)
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package pulid
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pixie-sh/errors-go"
+)
+
+// Generator creates ULIDs following a Layout, stamping the configured node identifier
+// and, when the layout reserves counter bits, a per tick counter
+type Generator struct {
+	layout  Layout
+	node    uint64
+	entropy io.Reader
+
+	mu      sync.Mutex
+	last    uint64
+	counter uint64
+}
+
+// NewGenerator returns a Generator for the layout and node identifier.
+// node must fit on the layout node bits
+func NewGenerator(layout Layout, node uint64, customEntropy ...io.Reader) (*Generator, error) {
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	if node > layout.MaxNode() {
+		return nil, errors.
+			New("node overflow; max %d < input %d", layout.MaxNode(), node).
+			WithErrorCode(InvalidNodeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidNode)
+	}
+
+	g := &Generator{
+		layout:  layout,
+		node:    node,
+		entropy: defaultEntropy,
+	}
+
+	if len(customEntropy) > 0 && customEntropy[0] != nil {
+		g.entropy = customEntropy[0]
+	}
+
+	return g, nil
+}
+
+// Layout returns the generator layout
+func (g *Generator) Layout() Layout {
+	return g.layout
+}
+
+// New returns a ULID filled with the layout max scope and the generator node
+func (g *Generator) New() (ULID, error) {
+	return g.NewScoped(ZeroedScopeValue)
+}
+
+// NewScoped returns a ULID filled with the input scope and the generator node.
+// When the layout has sub millisecond or counter bits, ids generated by the same
+// Generator are strictly ordered, even across goroutines. The counter follows the scope field,
+// so with counter bits the order only holds among ids of the same scope: within one tick
+// ids sort by scope first. Sub millisecond layouts without counter bits order every id
+func (g *Generator) NewScoped(scope Scope) (ULID, error) {
+	return g.newScoped(scope, g.entropy)
+}
+
+// newScoped is NewScoped reading the random bits from entropy
+func (g *Generator) newScoped(scope Scope, entropy io.Reader) (ULID, error) {
+	ticks, err := g.layout.ticks(time.Now())
+	if err != nil {
+		return EmptyUID, err
+	}
+
+	if !g.layout.ordered() {
+		return g.layout.newID(ticks, scope, g.node, 0, entropy)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// keep ids ordered when the clock goes backwards, ticks collide or the counter overflows
+	switch {
+	case ticks > g.last:
+		g.counter = 0
+	case g.counter < g.layout.MaxCounter():
+		ticks = g.last
+		g.counter++
+	default:
+		ticks = g.last + 1
+		g.counter = 0
+	}
+
+	g.last = ticks
+	return g.layout.newID(ticks, scope, g.node, g.counter, entropy)
+}
+
+func (g *Generator) MustNew() ULID {
+	id, err := g.New()
+	if err != nil {
+		panic(err)
+	}
+
+	return id
+}
+
+func (g *Generator) MustNewScoped(scope Scope) ULID {
+	id, err := g.NewScoped(scope)
+	if err != nil {
+		panic(err)
+	}
+
+	return id
+}
+
+var (
+	processMu  sync.Mutex
+	processGen *Generator
+)
+
+// processGenerator returns the process wide Generator backing New and NewScoped when DefaultLayout
+// has sub millisecond or counter bits; it is recreated, with node 0, when DefaultLayout changes
+func processGenerator() (*Generator, error) {
+	processMu.Lock()
+	defer processMu.Unlock()
+
+	if processGen == nil || processGen.layout != DefaultLayout {
+		g, err := NewGenerator(DefaultLayout, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		processGen = g
+	}
+
+	return processGen, nil
+}
@@ -2,29 +2,72 @@ package pulid
 
 import (
 	cryptoRand "crypto/rand"
+	goErrors "errors"
+	"time"
+
 	"github.com/pixie-sh/errors-go"
 )
 
 const (
 	textEncodedSize  = 26
 	uuidStringLength = 36
+	uuidHexLength    = 32
+	uuidBracedLength = uuidStringLength + 2
+	uuidURNLength    = uuidStringLength + len(uuidURNPrefix)
+	uuidURNPrefix    = "urn:uuid:"
 	ulid16Bytes      = 16
+
+	ulidBits     = 128
+	maxEpochBits = 48
+	maxScopeBits = 16
+
+	maxSubMillisBits = 16
 )
 
+// MinRandomBits minimum amount of random bits a Layout must keep
+const MinRandomBits = 32
+
 var (
 	EmptyUID         = ULID{}
 	MaxScopeValue    = Scope(65535)
 	ZeroedScopeValue = Scope(0)
+	DefaultLayout    = Layout{EpochBits: 48, ScopeBits: 16, RandomBits: 64}
+	SubMillisLayout  = Layout{EpochBits: 48, SubMillisBits: 12, ScopeBits: 16, RandomBits: 52}
+	MaxClockSkew     = time.Hour
+	// MinPlausibleTime earliest time VerifyEpoch accepts; zero disables the check
+	MinPlausibleTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// DefaultScopeSchema decodes scopes on Format %v; no fields by default
+	DefaultScopeSchema = ScopeSchema{}
+	// DefaultJSONFormat representation used by ULID.MarshalJSON
+	DefaultJSONFormat = JSONFormatULID
+	// DefaultLogVerbosity attributes emitted by ULID.LogValue
+	DefaultLogVerbosity = LogVerbosityGroup
+	// DefaultParseOptions UUID forms accepted by UnmarshalUUID, UnmarshalText and ParseUUID; only the canonical one by default
+	DefaultParseOptions = ParseOptions{}
 
 	defaultEntropy = cryptoRand.Reader
-	maxTime        = ULID{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}.Epoch()
 	leftPad        = [6]byte{1, 36, 47, 223, 23, 0}
 	encoding       = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	encodingLower  = "0123456789abcdefghjkmnpqrstvwxyz"
 
+	// error codes share the 412 HTTP status; the leading digits tell them apart
 	InvalidSizeULIDSystemErrorCode       = errors.NewErrorCode("InvalidSizeULIDSystemErrorCode", 90412)
-	InvalidTimeFormatULIDSystemErrorCode = errors.NewErrorCode("InvalidTimeFormatULIDSystemErrorCode", 90412)
-	InvalidCharsULIDSystemErrorCode      = errors.NewErrorCode("InvalidCharsULIDSystemErrorCode", 90412)
-	InvalidScopeULIDSystemErrorCode      = errors.NewErrorCode("InvalidScopeULIDSystemErrorCode", 90412)
+	InvalidTimeFormatULIDSystemErrorCode = errors.NewErrorCode("InvalidTimeFormatULIDSystemErrorCode", 91412)
+	InvalidCharsULIDSystemErrorCode      = errors.NewErrorCode("InvalidCharsULIDSystemErrorCode", 92412)
+	InvalidScopeULIDSystemErrorCode      = errors.NewErrorCode("InvalidScopeULIDSystemErrorCode", 93412)
+	InvalidNodeULIDSystemErrorCode       = errors.NewErrorCode("InvalidNodeULIDSystemErrorCode", 94412)
+	InvalidLayoutULIDSystemErrorCode     = errors.NewErrorCode("InvalidLayoutULIDSystemErrorCode", 95412)
+	InvalidJSONULIDSystemErrorCode       = errors.NewErrorCode("InvalidJSONULIDSystemErrorCode", 96412)
+
+	// sentinel errors, one per error code, nested first on every returned error so errors.Is matches them
+	ErrInvalidSize       = goErrors.New("pulid: invalid size")
+	ErrInvalidTimeFormat = goErrors.New("pulid: invalid time")
+	ErrInvalidChars      = goErrors.New("pulid: invalid characters")
+	ErrInvalidScope      = goErrors.New("pulid: invalid scope")
+	ErrInvalidNode       = goErrors.New("pulid: invalid node")
+	ErrInvalidLayout     = goErrors.New("pulid: invalid layout")
+	ErrInvalidJSON       = goErrors.New("pulid: invalid json")
 
 	// https://github.com/RobThree/NUlid/blob/master/NUlid/Ulid.cs
 	// static initialization to avoid allocations
@@ -0,0 +1,160 @@
+package pulid
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/pixie-sh/errors-go"
+)
+
+// JSONFormat selects the ULID JSON representation
+type JSONFormat uint8
+
+const (
+	// JSONFormatULID "01JJN1AD5B08VJ5SRBJAWCBWDQ"
+	JSONFormatULID JSONFormat = iota
+	// JSONFormatUUID "0194aa15-34ab-0237-22e7-0b92b8c5f1b7"
+	JSONFormatUUID
+	// JSONFormatPrefixed "usr_01JJN1AD5B08VJ5SRBJAWCBWDQ", prefixed with the name registered
+	// for the id scope through RegisterScopeName; unnamed scopes fall back to JSONFormatULID
+	JSONFormatPrefixed
+	// JSONFormatObject {"id":"01JJN1AD5B08VJ5SRBJAWCBWDQ","epoch":1738020304043,"scope":567}
+	JSONFormatObject
+)
+
+const prefixSeparator = '_'
+
+var jsonNull = []byte("null")
+
+// MarshalJSON encodes the id according to DefaultJSONFormat
+func (id ULID) MarshalJSON() ([]byte, error) {
+	return id.AppendJSON(make([]byte, 0, 64), DefaultJSONFormat)
+}
+
+// AppendJSON appends the id JSON representation, in the input format, to b
+func (id ULID) AppendJSON(b []byte, format JSONFormat) ([]byte, error) {
+	switch format {
+	case JSONFormatULID:
+		b = append(b, '"')
+		b, _ = id.AppendText(b)
+		return append(b, '"'), nil
+	case JSONFormatUUID:
+		b = append(b, '"')
+		b = id.AppendUUID(b)
+		return append(b, '"'), nil
+	case JSONFormatPrefixed:
+		b = append(b, '"')
+		if scope, err := id.Scope(); err == nil {
+			if name, ok := ScopeName(scope); ok {
+				b = append(b, name...)
+				b = append(b, prefixSeparator)
+			}
+		}
+		b, _ = id.AppendText(b)
+		return append(b, '"'), nil
+	case JSONFormatObject:
+		scope, _ := id.Scope()
+		b = append(b, `{"id":"`...)
+		b, _ = id.AppendText(b)
+		b = append(b, `","epoch":`...)
+		b = strconv.AppendUint(b, id.Epoch(), 10)
+		b = append(b, `,"scope":`...)
+		b = strconv.AppendUint(b, uint64(scope), 10)
+		return append(b, '}'), nil
+	default:
+		return nil, errors.
+			New("unknown json format %d", format).
+			WithErrorCode(InvalidJSONULIDSystemErrorCode).
+			WithNestedError(ErrInvalidJSON)
+	}
+}
+
+// UnmarshalJSON decodes any JSONFormat, regardless of DefaultJSONFormat:
+// ULID or UUID strings, prefixed strings, whose prefix must match the id scope name,
+// and objects with an "id" field. null leaves the id untouched; empty strings fail
+func (id *ULID) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case bytes.Equal(data, jsonNull):
+		return nil
+	case len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"':
+		raw := data[1 : len(data)-1]
+		if bytes.IndexByte(raw, '\\') >= 0 {
+			var s string
+			if err := json.Unmarshal(data, &s); err != nil {
+				return errors.
+					New("invalid json string").
+					WithErrorCode(InvalidJSONULIDSystemErrorCode).
+					WithNestedError(ErrInvalidJSON, err)
+			}
+			raw = []byte(s)
+		}
+
+		return id.unmarshalJSONString(raw)
+	case len(data) > 0 && data[0] == '{':
+		var obj struct {
+			ID *string `json:"id"`
+		}
+
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return errors.
+				New("invalid json object").
+				WithErrorCode(InvalidJSONULIDSystemErrorCode).
+				WithNestedError(ErrInvalidJSON, err)
+		}
+
+		if obj.ID == nil {
+			return errors.
+				New("json object missing the id field").
+				WithErrorCode(InvalidJSONULIDSystemErrorCode).
+				WithNestedError(ErrInvalidJSON)
+		}
+
+		return id.unmarshalJSONString([]byte(*obj.ID))
+	default:
+		return errors.
+			New("invalid json value; expected string, object or null").
+			WithErrorCode(InvalidJSONULIDSystemErrorCode).
+			WithNestedError(ErrInvalidJSON)
+	}
+}
+
+func (id *ULID) unmarshalJSONString(raw []byte) error {
+	if len(raw) == 0 {
+		return errors.
+			New("empty ULID string").
+			WithErrorCode(InvalidSizeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidSize)
+	}
+
+	sep := bytes.LastIndexByte(raw, prefixSeparator)
+	if sep < 0 {
+		return id.UnmarshalText(raw)
+	}
+
+	var parsed ULID
+	if err := parsed.UnmarshalText(raw[sep+1:]); err != nil {
+		return err
+	}
+
+	prefix := string(raw[:sep])
+	scope, ok := ScopeByName(prefix)
+	if !ok {
+		return errors.
+			New("unknown scope prefix '%s'", prefix).
+			WithErrorCode(InvalidScopeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidScope)
+	}
+
+	if idScope, _ := parsed.Scope(); idScope != scope {
+		return errors.
+			New("scope prefix '%s' (%d) does not match id scope %d", prefix, scope, idScope).
+			WithErrorCode(InvalidScopeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidScope)
+	}
+
+	*id = parsed
+	return nil
+}
@@ -0,0 +1,92 @@
+package pulid
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestJSONFormats(t *testing.T) {
+	defer func(format JSONFormat) { DefaultJSONFormat = format }(DefaultJSONFormat)
+
+	if err := RegisterScopeName(567, "usr"); err != nil {
+		t.Fatalf("Failed to register scope name: %v", err)
+	}
+
+	id := MustNewScoped(567)
+	expected := map[JSONFormat]string{
+		JSONFormatULID:     fmt.Sprintf(`"%s"`, id.String()),
+		JSONFormatUUID:     fmt.Sprintf(`"%s"`, id.UUID()),
+		JSONFormatPrefixed: fmt.Sprintf(`"usr_%s"`, id.String()),
+		JSONFormatObject:   fmt.Sprintf(`{"id":"%s","epoch":%d,"scope":567}`, id.String(), id.Epoch()),
+	}
+
+	for format, want := range expected {
+		DefaultJSONFormat = format
+
+		data, err := json.Marshal(id)
+		if err != nil {
+			t.Fatalf("JSON marshalling failed: %v", err)
+		}
+
+		if string(data) != want {
+			t.Fatalf("JSON format %d mismatch: expected %s got %s", format, want, data)
+		}
+
+		var decoded ULID
+		if err = json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("JSON unmarshalling of %s failed: %v", data, err)
+		}
+
+		if decoded != id {
+			t.Fatalf("JSON round trip failed: expected %v, got %v", id, decoded)
+		}
+	}
+
+	DefaultJSONFormat = JSONFormatPrefixed
+	unnamed := MustNewScoped(568)
+	if data, _ := json.Marshal(unnamed); string(data) != fmt.Sprintf(`"%s"`, unnamed.String()) {
+		t.Fatalf("Unnamed scopes should fall back to the ULID text, got %s", data)
+	}
+}
+
+func TestJSONNullAndErrors(t *testing.T) {
+	var holder struct {
+		ID ULID `json:"id"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":null}`), &holder); err != nil || holder.ID != EmptyUID {
+		t.Fatalf("null should leave the id empty, got %v; err %+v", holder.ID, err)
+	}
+
+	if err := RegisterScopeName(567, "usr"); err != nil {
+		t.Fatalf("Failed to register scope name: %v", err)
+	}
+
+	invalid := []string{
+		`""`,
+		`123`,
+		`{"epoch":1}`,
+		`"not-a-ulid"`,
+		`"unknown_` + MustNewScoped(567).String() + `"`,
+		`"usr_` + MustNewScoped(568).String() + `"`,
+	}
+
+	for _, data := range invalid {
+		var id ULID
+		if err := json.Unmarshal([]byte(data), &id); err == nil {
+			t.Fatalf("Expected error unmarshalling %s", data)
+		}
+	}
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	id := MustNew()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := id.MarshalJSON(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
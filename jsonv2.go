@@ -0,0 +1,34 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+// encoding/json/v2 is an experiment up to go1.26, see jsonv2_go125.go; go1.27 files are
+// versioned accordingly so go vet does not flag its API as too new for the module go version
+
+package pulid
+
+import (
+	"encoding/json/jsontext"
+)
+
+// MarshalJSONTo streams the id, according to DefaultJSONFormat, into the encoding/json/v2 encoder.
+// Output is byte-identical to MarshalJSON
+func (id ULID) MarshalJSONTo(enc *jsontext.Encoder) error {
+	var buf [96]byte
+
+	b, err := id.AppendJSON(buf[:0], DefaultJSONFormat)
+	if err != nil {
+		return err
+	}
+
+	return enc.WriteValue(b)
+}
+
+// UnmarshalJSONFrom reads the next value from the encoding/json/v2 decoder, accepting
+// the same inputs as UnmarshalJSON. The value is decoded in place, without allocating
+func (id *ULID) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+
+	return id.UnmarshalJSON(val)
+}
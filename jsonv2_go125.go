@@ -0,0 +1,29 @@
+//go:build goexperiment.jsonv2 && !go1.27
+
+package pulid
+
+import (
+	"encoding/json/jsontext"
+)
+
+// MarshalJSONTo is the go1.25 and go1.26 GOEXPERIMENT=jsonv2 counterpart of jsonv2.go
+func (id ULID) MarshalJSONTo(enc *jsontext.Encoder) error {
+	var buf [96]byte
+
+	b, err := id.AppendJSON(buf[:0], DefaultJSONFormat)
+	if err != nil {
+		return err
+	}
+
+	return enc.WriteValue(b)
+}
+
+// UnmarshalJSONFrom is the go1.25 and go1.26 GOEXPERIMENT=jsonv2 counterpart of jsonv2.go
+func (id *ULID) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+
+	return id.UnmarshalJSON(val)
+}
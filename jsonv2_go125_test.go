@@ -0,0 +1,12 @@
+//go:build goexperiment.jsonv2 && !go1.27
+
+package pulid
+
+import (
+	"encoding/json/v2"
+)
+
+func init() {
+	jsonv2Marshal = func(v any) ([]byte, error) { return json.Marshal(v) }
+	jsonv2Unmarshal = func(data []byte, v any) error { return json.Unmarshal(data, v) }
+}
@@ -0,0 +1,111 @@
+package pulid
+
+import (
+	"bytes"
+	jsonv1 "encoding/json"
+	"testing"
+)
+
+// jsonv2Marshal and jsonv2Unmarshal are encoding/json/v2 Marshal and Unmarshal when built with it, set by
+// jsonv2_go127_test.go or jsonv2_go125_test.go; the tests are shared so each go version runs the same ones
+var (
+	jsonv2Marshal   func(any) ([]byte, error)
+	jsonv2Unmarshal func([]byte, any) error
+)
+
+func skipWithoutJSONv2(tb testing.TB) {
+	tb.Helper()
+
+	if jsonv2Marshal == nil {
+		tb.Skip("built without encoding/json/v2; set GOEXPERIMENT=jsonv2 on go1.25 and go1.26")
+	}
+}
+
+func TestJSONv2MatchesV1(t *testing.T) {
+	skipWithoutJSONv2(t)
+
+	defer func(format JSONFormat) { DefaultJSONFormat = format }(DefaultJSONFormat)
+
+	if err := RegisterScopeName(567, "usr"); err != nil {
+		t.Fatalf("Failed to register scope name: %v", err)
+	}
+
+	ids := []ULID{MustNewScoped(567), MustNewScoped(568), MustNew(), EmptyUID}
+	for _, format := range []JSONFormat{JSONFormatULID, JSONFormatUUID, JSONFormatPrefixed, JSONFormatObject} {
+		DefaultJSONFormat = format
+
+		v1, err := jsonv1.Marshal(ids)
+		if err != nil {
+			t.Fatalf("JSON v1 marshalling failed: %v", err)
+		}
+
+		v2, err := jsonv2Marshal(ids)
+		if err != nil {
+			t.Fatalf("JSON v2 marshalling failed: %v", err)
+		}
+
+		if !bytes.Equal(v1, v2) {
+			t.Fatalf("JSON v2 output differs from v1 for format %d:\n%s\n%s", format, v1, v2)
+		}
+
+		var decoded []ULID
+		if err = jsonv2Unmarshal(v2, &decoded); err != nil {
+			t.Fatalf("JSON v2 unmarshalling failed: %v", err)
+		}
+
+		for i := range ids[:3] {
+			if decoded[i] != ids[i] {
+				t.Fatalf("JSON v2 round trip failed: expected %v, got %v", ids[i], decoded[i])
+			}
+		}
+	}
+}
+
+func TestJSONv2DecodeDoesNotAllocatePerElement(t *testing.T) {
+	skipWithoutJSONv2(t)
+
+	var ids [1000]ULID
+	for i := range ids {
+		ids[i] = MustNewScoped(567)
+	}
+
+	data, err := jsonv2Marshal(ids)
+	if err != nil {
+		t.Fatalf("JSON v2 marshalling failed: %v", err)
+	}
+
+	var decoded [1000]ULID
+	allocs := testing.AllocsPerRun(10, func() {
+		if err := jsonv2Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("JSON v2 unmarshalling failed: %v", err)
+		}
+	})
+
+	if allocs >= float64(len(ids)) {
+		t.Fatalf("Expected less than one allocation per element, got %.0f for %d ids", allocs, len(ids))
+	}
+
+	if decoded != ids {
+		t.Fatalf("JSON v2 array round trip failed")
+	}
+}
+
+func BenchmarkJSONv2UnmarshalArray(b *testing.B) {
+	skipWithoutJSONv2(b)
+
+	var ids [1000]ULID
+	for i := range ids {
+		ids[i] = MustNew()
+	}
+
+	data, _ := jsonv2Marshal(ids)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var decoded [1000]ULID
+		if err := jsonv2Unmarshal(data, &decoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package pulid
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pixie-sh/errors-go"
+)
+
+// Layout describes how the 128 bits of a ULID are split between fields.
+// Fields are laid out from the most significant bit, in declaration order.
+// Sub millisecond bits follow the epoch so ids keep ordered by time, as UUIDv7 method 3.
+//
+//	DefaultLayout:
+//	  6bytes   2bytes    8bytes
+//	| ------ | | -- | | -------- |
+//	   epoch.  scope    random
+type Layout struct {
+	// EpochBits amount of bits storing the milliseconds timestamp
+	EpochBits uint8
+	// SubMillisBits amount of bits storing the sub millisecond fraction of the timestamp
+	SubMillisBits uint8
+	// ScopeBits amount of bits storing the scope
+	ScopeBits uint8
+	// NodeBits amount of bits storing the node identifier
+	NodeBits uint8
+	// CounterBits amount of bits storing the per tick counter
+	CounterBits uint8
+	// RandomBits amount of random bits
+	RandomBits uint8
+
+	// Epoch custom epoch timestamps are relative to; zero value means the Unix epoch.
+	// A recent epoch, e.g. 2024-01-01, allows fewer EpochBits for the same range
+	Epoch time.Time
+}
+
+// Validate checks the layout fields sum to 128 bits and that enough random bits remain
+func (l Layout) Validate() error {
+	total := uint(l.EpochBits) + uint(l.SubMillisBits) + uint(l.ScopeBits) + uint(l.NodeBits) + uint(l.CounterBits) + uint(l.RandomBits)
+
+	switch {
+	case total != ulidBits:
+		return errors.
+			New("invalid layout; fields sum %d bits instead of %d", total, ulidBits).
+			WithErrorCode(InvalidLayoutULIDSystemErrorCode).
+			WithNestedError(ErrInvalidLayout)
+	case l.EpochBits == 0 || l.EpochBits > maxEpochBits:
+		return errors.
+			New("invalid layout; epoch bits must be within 1 and %d, got %d", maxEpochBits, l.EpochBits).
+			WithErrorCode(InvalidLayoutULIDSystemErrorCode).
+			WithNestedError(ErrInvalidLayout)
+	case l.SubMillisBits > maxSubMillisBits:
+		return errors.
+			New("invalid layout; sub millisecond bits must be up to %d, got %d", maxSubMillisBits, l.SubMillisBits).
+			WithErrorCode(InvalidLayoutULIDSystemErrorCode).
+			WithNestedError(ErrInvalidLayout)
+	case l.ScopeBits > maxScopeBits:
+		return errors.
+			New("invalid layout; scope bits must be up to %d, got %d", maxScopeBits, l.ScopeBits).
+			WithErrorCode(InvalidLayoutULIDSystemErrorCode).
+			WithNestedError(ErrInvalidLayout)
+	case l.NodeBits > 64 || l.CounterBits > 64:
+		return errors.
+			New("invalid layout; node and counter bits must be up to 64, got %d and %d", l.NodeBits, l.CounterBits).
+			WithErrorCode(InvalidLayoutULIDSystemErrorCode).
+			WithNestedError(ErrInvalidLayout)
+	case !l.Epoch.IsZero() && l.Epoch.UnixMilli() < 0:
+		return errors.
+			New("invalid layout; custom epoch %s is before the Unix epoch", l.Epoch.Format(time.RFC3339)).
+			WithErrorCode(InvalidLayoutULIDSystemErrorCode).
+			WithNestedError(ErrInvalidLayout)
+	case l.RandomBits < MinRandomBits:
+		return errors.
+			New("invalid layout; %d random bits is less than the minimum %d", l.RandomBits, MinRandomBits).
+			WithErrorCode(InvalidLayoutULIDSystemErrorCode).
+			WithNestedError(ErrInvalidLayout)
+	}
+
+	return nil
+}
+
+// MaxEpoch returns the max milliseconds timestamp the layout is able to store,
+// relative to the layout epoch
+func (l Layout) MaxEpoch() uint64 {
+	return bitMask(l.EpochBits)
+}
+
+// epochOffset returns the layout epoch as Unix milliseconds
+func (l Layout) epochOffset() uint64 {
+	if l.Epoch.IsZero() {
+		return 0
+	}
+
+	return uint64(l.Epoch.UnixMilli())
+}
+
+// MaxScope returns the max scope the layout is able to store
+func (l Layout) MaxScope() Scope {
+	return Scope(bitMask(l.ScopeBits))
+}
+
+// MaxNode returns the max node identifier the layout is able to store
+func (l Layout) MaxNode() uint64 {
+	return bitMask(l.NodeBits)
+}
+
+// MaxCounter returns the max counter the layout is able to store
+func (l Layout) MaxCounter() uint64 {
+	return bitMask(l.CounterBits)
+}
+
+func (l Layout) scopeOffset() uint {
+	return uint(l.EpochBits) + uint(l.SubMillisBits)
+}
+
+func (l Layout) nodeOffset() uint {
+	return l.scopeOffset() + uint(l.ScopeBits)
+}
+
+func (l Layout) counterOffset() uint {
+	return l.nodeOffset() + uint(l.NodeBits)
+}
+
+// ordered reports whether the layout needs a Generator to keep ids ordered
+func (l Layout) ordered() bool {
+	return l.SubMillisBits > 0 || l.CounterBits > 0
+}
+
+// ticks returns the timestamp on the layout precision, relative to the layout epoch:
+// milliseconds followed by the sub millisecond fraction
+func (l Layout) ticks(t time.Time) (uint64, error) {
+	var (
+		ms   = uint64(t.Unix())*1000 + uint64(t.Nanosecond()/int(time.Millisecond))
+		frac = uint64(t.Nanosecond()%int(time.Millisecond)) << l.SubMillisBits / uint64(time.Millisecond)
+	)
+
+	if t.Unix() < 0 || ms < l.epochOffset() {
+		return 0, errors.
+			New("time %s is before the layout epoch", t.Format(time.RFC3339Nano)).
+			WithErrorCode(InvalidTimeFormatULIDSystemErrorCode).
+			WithNestedError(ErrInvalidTimeFormat)
+	}
+
+	return (ms-l.epochOffset())<<l.SubMillisBits | frac, nil
+}
+
+// newID fills a ULID with the layout fields, reading the random bits from entropy
+func (l Layout) newID(ticks uint64, scope Scope, node, counter uint64, entropy io.Reader) (ULID, error) {
+	var id = EmptyUID
+
+	if scope > l.MaxScope() {
+		return EmptyUID, errors.
+			New("scope value overflow; max %d < input %d", l.MaxScope(), scope).
+			WithErrorCode(InvalidScopeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidScope)
+	}
+
+	if scope == ZeroedScopeValue {
+		scope = l.MaxScope()
+	}
+
+	// random bits are right aligned; read only the bytes holding them
+	if _, err := entropy.Read(id[(ulidBits-uint(l.RandomBits))/8:]); err != nil {
+		return id, err
+	}
+
+	if err := id.setTime(ticks, l); err != nil {
+		return id, err
+	}
+
+	id.writeBits(l.scopeOffset(), uint(l.ScopeBits), uint64(scope))
+	id.writeBits(l.nodeOffset(), uint(l.NodeBits), node)
+	id.writeBits(l.counterOffset(), uint(l.CounterBits), counter)
+
+	return id, nil
+}
+
+// MinAt returns the smallest id the layout is able to produce on the millisecond of t, whatever its scope.
+// Pair it with MaxAt to query ids by time window, e.g. WHERE id BETWEEN min AND max;
+// the bounds hold ids of every scope, filter the scope with its own predicate
+func (l Layout) MinAt(t time.Time) (ULID, error) {
+	return l.boundAt(t, 0x00)
+}
+
+// MaxAt returns the largest id the layout is able to produce on the millisecond of t, whatever its scope,
+// sub millisecond bits included
+func (l Layout) MaxAt(t time.Time) (ULID, error) {
+	return l.boundAt(t, 0xFF)
+}
+
+// boundAt fills every field but the milliseconds with fill
+func (l Layout) boundAt(t time.Time, fill byte) (ULID, error) {
+	var id ULID
+
+	if err := l.Validate(); err != nil {
+		return EmptyUID, err
+	}
+
+	ticks, err := l.ticks(t)
+	if err != nil {
+		return EmptyUID, err
+	}
+
+	// bounds cover the whole millisecond; sub millisecond bits follow fill as the other fields
+	ticks &^= bitMask(l.SubMillisBits)
+	if fill != 0x00 {
+		ticks |= bitMask(l.SubMillisBits)
+	}
+
+	for i := range id {
+		id[i] = fill
+	}
+
+	if err = id.setTime(ticks, l); err != nil {
+		return EmptyUID, err
+	}
+
+	return id, nil
+}
+
+// Node returns the node identifier stored on the id according to the layout
+func (id ULID) Node(layout Layout) (uint64, error) {
+	if err := layout.Validate(); err != nil {
+		return 0, err
+	}
+
+	if layout.NodeBits == 0 {
+		return 0, errors.
+			New("layout does not reserve node bits").
+			WithErrorCode(InvalidNodeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidNode)
+	}
+
+	return id.readBits(layout.nodeOffset(), uint(layout.NodeBits)), nil
+}
+
+// Counter returns the counter stored on the id according to the layout
+func (id ULID) Counter(layout Layout) (uint64, error) {
+	if err := layout.Validate(); err != nil {
+		return 0, err
+	}
+
+	if layout.CounterBits == 0 {
+		return 0, errors.
+			New("layout does not reserve counter bits").
+			WithErrorCode(InvalidLayoutULIDSystemErrorCode).
+			WithNestedError(ErrInvalidLayout)
+	}
+
+	return id.readBits(layout.counterOffset(), uint(layout.CounterBits)), nil
+}
+
+// NodeIDFromHostname derives a node identifier from the FNV-1a hash of the hostname,
+// truncated to the layout node bits
+func NodeIDFromHostname(layout Layout) (uint64, error) {
+	host, err := os.Hostname()
+	if err != nil {
+		return 0, errors.
+			New("unable to read hostname").
+			WithErrorCode(InvalidNodeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidNode, err)
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(host))
+
+	return h.Sum64() & layout.MaxNode(), nil
+}
+
+// NodeIDFromEnv reads a node identifier from the environment variable key
+func NodeIDFromEnv(layout Layout, key string) (uint64, error) {
+	raw, ok := os.LookupEnv(key)
+	if !ok {
+		return 0, errors.
+			New("env var %s not set", key).
+			WithErrorCode(InvalidNodeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidNode)
+	}
+
+	node, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, errors.
+			New("invalid node identifier on env var %s", key).
+			WithErrorCode(InvalidNodeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidNode, err)
+	}
+
+	if node > layout.MaxNode() {
+		return 0, errors.
+			New("node overflow; max %d < input %d", layout.MaxNode(), node).
+			WithErrorCode(InvalidNodeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidNode)
+	}
+
+	return node, nil
+}
+
+func layoutOrDefault(layout []Layout) Layout {
+	if len(layout) > 0 {
+		return layout[0]
+	}
+
+	return DefaultLayout
+}
+
+// readBits returns width bits starting at offset, counted from the most significant bit
+func (id ULID) readBits(offset, width uint) uint64 {
+	var (
+		hi    = binary.BigEndian.Uint64(id[:8])
+		lo    = binary.BigEndian.Uint64(id[8:])
+		shift = ulidBits - offset - width
+		v     uint64
+	)
+
+	if shift >= 64 {
+		v = hi >> (shift - 64)
+	} else {
+		v = lo>>shift | hi<<(64-shift)
+	}
+
+	return v & bitMask(uint8(width))
+}
+
+// writeBits stores the width least significant bits of v starting at offset,
+// counted from the most significant bit
+func (id *ULID) writeBits(offset, width uint, v uint64) {
+	var (
+		hi    = binary.BigEndian.Uint64(id[:8])
+		lo    = binary.BigEndian.Uint64(id[8:])
+		mask  = bitMask(uint8(width))
+		shift = ulidBits - offset - width
+	)
+
+	v &= mask
+	if shift >= 64 {
+		hi = hi&^(mask<<(shift-64)) | v<<(shift-64)
+	} else {
+		lo = lo&^(mask<<shift) | v<<shift
+		if shift > 0 {
+			hi = hi&^(mask>>(64-shift)) | v>>(64-shift)
+		}
+	}
+
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+}
+
+func bitMask(width uint8) uint64 {
+	if width >= 64 {
+		return ^uint64(0)
+	}
+
+	return 1<<width - 1
+}
@@ -0,0 +1,408 @@
+package pulid
+
+import (
+	"bytes"
+	"crypto/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+var nodeLayout = Layout{EpochBits: 48, ScopeBits: 16, NodeBits: 10, RandomBits: 54}
+
+func TestLayoutValidation(t *testing.T) {
+	if err := DefaultLayout.Validate(); err != nil {
+		t.Fatalf("Default layout should be valid: %v", err)
+	}
+
+	if err := (Layout{EpochBits: 48, ScopeBits: 16, NodeBits: 32, RandomBits: 32}).Validate(); err != nil {
+		t.Fatalf("32 node bits should leave enough random bits: %v", err)
+	}
+
+	invalid := []Layout{
+		{EpochBits: 48, ScopeBits: 16, NodeBits: 33, RandomBits: 31},
+		{EpochBits: 48, ScopeBits: 16, RandomBits: 60},
+		{EpochBits: 49, ScopeBits: 15, RandomBits: 64},
+		{EpochBits: 40, ScopeBits: 17, RandomBits: 71},
+		{ScopeBits: 16, RandomBits: 112},
+	}
+
+	for _, l := range invalid {
+		if err := l.Validate(); err == nil {
+			t.Fatalf("Expected error for invalid layout %+v", l)
+		}
+	}
+}
+
+func TestDefaultLayoutMatchesFixedLayout(t *testing.T) {
+	id := MustNewScoped(567)
+
+	if id.Epoch() != id.Epoch(DefaultLayout) {
+		t.Fatalf("Epoch mismatch between default and explicit layout")
+	}
+
+	if id[6] != 0x02 || id[7] != 0x37 {
+		t.Fatalf("Scope not stored on the 7th and 8th bytes: %X", id[:])
+	}
+}
+
+func TestGeneratorNode(t *testing.T) {
+	if _, err := NewGenerator(nodeLayout, 1024); err == nil {
+		t.Fatalf("Expected error for node overflowing %d bits", nodeLayout.NodeBits)
+	}
+
+	gen, err := NewGenerator(nodeLayout, 713)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	for i := 0; i < 1000; i++ {
+		id, err := gen.NewScoped(567)
+		if err != nil {
+			t.Fatalf("Failed to generate ULID: %v", err)
+		}
+
+		node, err := id.Node(nodeLayout)
+		if err != nil || node != 713 {
+			t.Fatalf("ULID node is incorrect, expected 713 got %d; err %+v", node, err)
+		}
+
+		if scope, err := id.Scope(nodeLayout); err != nil || scope != 567 {
+			t.Fatalf("ULID scope is incorrect, expected 567 got %d; err %+v", scope, err)
+		}
+	}
+
+	if _, err := MustNew().Node(DefaultLayout); err == nil {
+		t.Fatalf("Expected error reading node from a layout without node bits")
+	}
+}
+
+func TestGeneratorCounter(t *testing.T) {
+	layout := Layout{EpochBits: 48, ScopeBits: 8, CounterBits: 8, RandomBits: 64}
+
+	gen, err := NewGenerator(layout, 0)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	prev := gen.MustNewScoped(12)
+	for i := 0; i < 10_000; i++ {
+		id := gen.MustNewScoped(12)
+		if bytes.Compare(prev[:10], id[:10]) >= 0 {
+			t.Fatalf("ULIDs not ordered: %v >= %v", prev, id)
+		}
+
+		if scope, err := id.Scope(layout); err != nil || scope != 12 {
+			t.Fatalf("ULID scope is incorrect, expected 12 got %d; err %+v", scope, err)
+		}
+
+		prev = id
+	}
+
+	// within one tick the scope sorts before the counter: ordering holds per scope only
+	last := map[Scope]ULID{}
+	for i := 0; i < 10_000; i++ {
+		scope := Scope(5 - i%2*2)
+		id := gen.MustNewScoped(scope)
+		if prev, ok := last[scope]; ok && bytes.Compare(prev[:10], id[:10]) >= 0 {
+			t.Fatalf("ULIDs of scope %d not ordered: %v >= %v", scope, prev, id)
+		}
+
+		last[scope] = id
+	}
+
+	a, b := gen.MustNewScoped(5), gen.MustNewScoped(3)
+	if a.Epoch(layout) == b.Epoch(layout) && bytes.Compare(a[:10], b[:10]) <= 0 {
+		t.Fatalf("Expected scope 5 to sort after scope 3 within the same tick: %v, %v", a, b)
+	}
+
+	if _, err = gen.NewScoped(256); err == nil {
+		t.Fatalf("Expected error for scope overflowing %d bits", layout.ScopeBits)
+	}
+}
+
+func TestNodeIDFromEnv(t *testing.T) {
+	layout := Layout{EpochBits: 48, ScopeBits: 16, NodeBits: 8, RandomBits: 56}
+
+	t.Setenv("PULID_NODE", "42")
+	node, err := NodeIDFromEnv(layout, "PULID_NODE")
+	if err != nil || node != 42 {
+		t.Fatalf("Expected node 42 got %d; err %+v", node, err)
+	}
+
+	t.Setenv("PULID_NODE", "256")
+	if _, err = NodeIDFromEnv(layout, "PULID_NODE"); err == nil {
+		t.Fatalf("Expected error for node overflowing %d bits", layout.NodeBits)
+	}
+
+	node, err = NodeIDFromHostname(layout)
+	if err != nil || node > layout.MaxNode() {
+		t.Fatalf("Invalid hostname node %d; err %+v", node, err)
+	}
+}
+
+func TestBitsReadWrite(t *testing.T) {
+	var id ULID
+
+	id.writeBits(60, 12, 0xABC)
+	if got := id.readBits(60, 12); got != 0xABC {
+		t.Fatalf("Expected 0xABC got %X", got)
+	}
+
+	if id[7] != 0x0A || id[8] != 0xBC {
+		t.Fatalf("Bits written on the wrong position: %X", id[:])
+	}
+
+	id.writeBits(64, 64, ^uint64(0))
+	if got := id.readBits(64, 64); got != ^uint64(0) {
+		t.Fatalf("Expected all ones got %X", got)
+	}
+}
+
+func TestSubMillisTime(t *testing.T) {
+	at := time.Date(2025, 1, 27, 23, 18, 8, 350_123_456, time.UTC)
+
+	id, err := SubMillisLayout.newID(mustTicks(t, SubMillisLayout, at), 567, 0, 0, defaultEntropy)
+	if err != nil {
+		t.Fatalf("Failed to generate ULID: %v", err)
+	}
+
+	if id.Epoch(SubMillisLayout) != uint64(at.UnixMilli()) {
+		t.Fatalf("Epoch mismatch: expected %d got %d", at.UnixMilli(), id.Epoch(SubMillisLayout))
+	}
+
+	// 12 bits resolve ~244ns
+	if diff := id.Time(SubMillisLayout).Sub(at); diff < -245*time.Nanosecond || diff > 245*time.Nanosecond {
+		t.Fatalf("Time mismatch: expected %v got %v", at, id.Time(SubMillisLayout))
+	}
+
+	if scope, err := id.Scope(SubMillisLayout); err != nil || scope != 567 {
+		t.Fatalf("ULID scope is incorrect, expected 567 got %d; err %+v", scope, err)
+	}
+
+	if ts := MustNew().Time(); ts.Nanosecond()%int(time.Millisecond) != 0 {
+		t.Fatalf("Default layout time should have millisecond precision, got %v", ts)
+	}
+}
+
+func TestSubMillisConcurrentOrdering(t *testing.T) {
+	const goroutines = 16
+	const idsPerRoutine = 10_000
+
+	gen, err := NewGenerator(SubMillisLayout, 0)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	var (
+		mu  sync.Mutex
+		all = make([]ULID, 0, goroutines*idsPerRoutine)
+		wg  sync.WaitGroup
+	)
+
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+
+			prev := EmptyUID
+			for j := 0; j < idsPerRoutine; j++ {
+				id := gen.MustNewScoped(Scope(j%100 + 1))
+				if bytes.Compare(prev[:], id[:]) >= 0 {
+					t.Errorf("ULIDs not ordered: %v >= %v", prev, id)
+					return
+				}
+
+				mu.Lock()
+				all = append(all, id)
+				mu.Unlock()
+				prev = id
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[uint64]struct{}, len(all))
+	for _, id := range all {
+		ticks := id.readBits(0, 60)
+		if _, ok := seen[ticks]; ok {
+			t.Fatalf("Duplicate timestamp detected: %v", id)
+		}
+		seen[ticks] = struct{}{}
+	}
+}
+
+func TestProcessGeneratorConcurrentOrdering(t *testing.T) {
+	const goroutines = 16
+	const idsPerRoutine = 10_000
+
+	defer func(l Layout) { DefaultLayout = l }(DefaultLayout)
+	DefaultLayout = SubMillisLayout
+
+	var (
+		mu  sync.Mutex
+		all = make([]ULID, 0, goroutines*idsPerRoutine)
+		wg  sync.WaitGroup
+	)
+
+	// package level ids share the process wide generator: ordered per goroutine, unique ticks overall
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+
+			prev := EmptyUID
+			for j := 0; j < idsPerRoutine; j++ {
+				id := MustNewScoped(Scope(j%100 + 1))
+				if bytes.Compare(prev[:], id[:]) >= 0 {
+					t.Errorf("ULIDs not ordered: %v >= %v", prev, id)
+					return
+				}
+
+				mu.Lock()
+				all = append(all, id)
+				mu.Unlock()
+				prev = id
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[uint64]struct{}, len(all))
+	for _, id := range all {
+		ticks := id.readBits(0, 60)
+		if _, ok := seen[ticks]; ok {
+			t.Fatalf("Duplicate timestamp detected: %v", id)
+		}
+		seen[ticks] = struct{}{}
+	}
+
+	// a layout change replaces the process generator
+	DefaultLayout = Layout{EpochBits: 48, ScopeBits: 8, CounterBits: 8, RandomBits: 64}
+	if id := MustNewScoped(12); id.readBits(DefaultLayout.counterOffset(), 8) != 0 {
+		t.Fatalf("Expected a fresh counter after the layout change, got %v", id)
+	}
+}
+
+func TestCustomEpoch(t *testing.T) {
+	layout := Layout{
+		EpochBits:  40,
+		ScopeBits:  16,
+		RandomBits: 72,
+		Epoch:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	gen, err := NewGenerator(layout, 0)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	now := time.Now()
+	id := gen.MustNewScoped(567)
+
+	if diff := id.Time(layout).Sub(now); diff < -time.Millisecond || diff > time.Second {
+		t.Fatalf("Time mismatch: expected %v got %v", now, id.Time(layout))
+	}
+
+	if id.Epoch(layout) != uint64(id.Time(layout).UnixMilli()) {
+		t.Fatalf("Epoch should be returned as Unix milliseconds, got %d", id.Epoch(layout))
+	}
+
+	if err = id.VerifyEpoch(layout); err != nil {
+		t.Fatalf("Unexpected epoch verification error: %v", err)
+	}
+
+	if _, err = UnmarshalStringWithLayout(MustNew().String(), Layout{
+		EpochBits:  48,
+		ScopeBits:  16,
+		RandomBits: 64,
+		Epoch:      layout.Epoch,
+	}); err == nil {
+		t.Fatalf("Expected error decoding a Unix epoch ULID with a custom epoch layout")
+	}
+
+	custom := Layout{EpochBits: 48, ScopeBits: 16, RandomBits: 64, Epoch: layout.Epoch}
+	customGen, err := NewGenerator(custom, 0)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	customID := customGen.MustNewScoped(567)
+	if _, err = UnmarshalStringWithLayout(customID.String(), custom); err != nil {
+		t.Fatalf("Unexpected error decoding a custom epoch ULID with its layout: %v", err)
+	}
+
+	if _, err = UnmarshalStringWithLayout(customID.String(), DefaultLayout); err == nil {
+		t.Fatalf("Expected error decoding a custom epoch ULID, dated %s, with the Unix epoch layout", customID.Time())
+	}
+
+	if _, err = layout.ticks(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Fatalf("Expected error for time before the layout epoch")
+	}
+}
+
+func mustTicks(t *testing.T, layout Layout, at time.Time) uint64 {
+	t.Helper()
+
+	ticks, err := layout.ticks(at)
+	if err != nil {
+		t.Fatalf("Failed to compute ticks: %v", err)
+	}
+
+	return ticks
+}
+
+func TestMinMaxAt(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	lo, err := MinAt(from)
+	if err != nil {
+		t.Fatalf("MinAt failed: %v", err)
+	}
+
+	hi, err := MaxAt(to)
+	if err != nil {
+		t.Fatalf("MaxAt failed: %v", err)
+	}
+
+	// ids of the lowest and highest scopes on the edge milliseconds stay within the bounds
+	for _, at := range []time.Time{from, from.Add(time.Minute), to, to.Add(time.Millisecond - time.Nanosecond)} {
+		for _, scope := range []Scope{1, 567, MaxScopeValue} {
+			id, _ := NewScopedAt(at, scope)
+			if bytes.Compare(id[:], lo[:]) < 0 || bytes.Compare(id[:], hi[:]) > 0 {
+				t.Fatalf("Id %v of scope %d at %s outside [%v, %v]", id, scope, at, lo, hi)
+			}
+		}
+	}
+
+	for _, at := range []time.Time{from.Add(-time.Millisecond), to.Add(time.Millisecond)} {
+		for _, scope := range []Scope{1, MaxScopeValue} {
+			id, _ := NewScopedAt(at, scope)
+			if bytes.Compare(id[:], lo[:]) >= 0 && bytes.Compare(id[:], hi[:]) <= 0 {
+				t.Fatalf("Id %v of scope %d at %s should be outside [%v, %v]", id, scope, at, lo, hi)
+			}
+		}
+	}
+
+	if lo != (ULID{0x01, 0x94, 0x1f, 0x29, 0x7c, 0x00}) {
+		t.Fatalf("Min bound should zero every field but the time, got %x", lo[:])
+	}
+
+	if hi.Epoch() != uint64(to.UnixMilli()) || hi[6] != 0xFF || hi[15] != 0xFF {
+		t.Fatalf("Max bound should fill every field but the time, got %x", hi[:])
+	}
+
+	// sub millisecond bounds span the whole millisecond of the passed time
+	subLo, _ := SubMillisLayout.MinAt(from.Add(time.Millisecond - time.Nanosecond))
+	subHi, _ := SubMillisLayout.MaxAt(to)
+	first, _ := SubMillisLayout.newID(mustTicks(t, SubMillisLayout, from), 567, 0, 0, rand.Reader)
+	last, _ := SubMillisLayout.newID(mustTicks(t, SubMillisLayout, to.Add(time.Millisecond-time.Nanosecond)), 567, 0, 0, rand.Reader)
+	if bytes.Compare(first[:], subLo[:]) < 0 || bytes.Compare(last[:], subHi[:]) > 0 {
+		t.Fatalf("Ids %v and %v on the window edge milliseconds outside [%v, %v]", first, last, subLo, subHi)
+	}
+
+	if _, err = SubMillisLayout.MaxAt(time.Unix(-1, 0)); err == nil {
+		t.Fatalf("Expected error for times before the epoch")
+	}
+}
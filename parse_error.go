@@ -0,0 +1,112 @@
+package pulid
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pixie-sh/errors-go"
+)
+
+// ParseError details why a text input failed to parse. Parsers return it nested on an errors-go error,
+// which also carries it as a FieldError; retrieve it with errors.As. It unwraps to ErrInvalidChars or ErrInvalidSize
+type ParseError struct {
+	// Input the rejected text
+	Input string
+	// Expected format: "ULID", "UUID" or an Encoding name
+	Expected string
+	// Index of the first offending byte; for length mismatches, the shorter of both lengths
+	Index int
+	// Char the offending byte, 0 when Index is past the input end
+	Char byte
+	// Reason "charset", "length" or "overflow"
+	Reason string
+
+	err error
+}
+
+const (
+	parseExpectedULID = "ULID"
+	parseExpectedUUID = "UUID"
+
+	parseReasonCharset  = "charset"
+	parseReasonLength   = "length"
+	parseReasonOverflow = "overflow"
+)
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("pulid: parsing %q as %s: %s", e.Input, e.Expected, e.message())
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.err
+}
+
+// FieldError returns the error as an errors-go field error: Field is the expected format,
+// Rule the reason and Param the offending index
+func (e *ParseError) FieldError() *errors.FieldError {
+	return &errors.FieldError{
+		Field:   e.Expected,
+		Rule:    e.Reason,
+		Param:   strconv.Itoa(e.Index),
+		Message: e.message(),
+	}
+}
+
+func (e *ParseError) message() string {
+	switch e.Reason {
+	case parseReasonLength:
+		return fmt.Sprintf("invalid length %d", len(e.Input))
+	case parseReasonOverflow:
+		return fmt.Sprintf("value overflows 128 bits, first character %q", e.Char)
+	default:
+		return fmt.Sprintf("invalid character %q at index %d", e.Char, e.Index)
+	}
+}
+
+// invalidCharError reports the invalid character found at index i of input
+func invalidCharError[T ~string | ~[]byte](expected string, input T, i int) errors.E {
+	return newParseError(&ParseError{
+		Input:    string(input),
+		Expected: expected,
+		Index:    i,
+		Char:     input[i],
+		Reason:   parseReasonCharset,
+		err:      ErrInvalidChars,
+	}, InvalidCharsULIDSystemErrorCode)
+}
+
+// invalidLengthError reports an input whose length is not size
+func invalidLengthError[T ~string | ~[]byte](expected string, input T, size int) errors.E {
+	e := &ParseError{
+		Input:    string(input),
+		Expected: expected,
+		Index:    min(len(input), size),
+		Reason:   parseReasonLength,
+		err:      ErrInvalidSize,
+	}
+
+	if e.Index < len(input) {
+		e.Char = input[e.Index]
+	}
+
+	return newParseError(e, InvalidSizeULIDSystemErrorCode)
+}
+
+// overflowError reports an input whose value exceeds 128 bits, e.g. ULID text starting above '7'
+func overflowError[T ~string | ~[]byte](expected string, input T) errors.E {
+	return newParseError(&ParseError{
+		Input:    string(input),
+		Expected: expected,
+		Index:    0,
+		Char:     input[0],
+		Reason:   parseReasonOverflow,
+		err:      ErrInvalidSize,
+	}, InvalidSizeULIDSystemErrorCode)
+}
+
+func newParseError(pe *ParseError, code errors.ErrorCode) errors.E {
+	return errors.
+		New("invalid %s: %s", pe.Expected, pe.message(), pe.FieldError()).
+		WithErrorCode(code).
+		WithNestedError(pe)
+}
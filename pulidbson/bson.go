@@ -0,0 +1,95 @@
+// Package pulidbson encodes pulid.ULID as BSON binary subtype 4 (UUID).
+package pulidbson
+
+import (
+	"reflect"
+
+	"github.com/pixie-sh/errors-go"
+	pulid "github.com/pixie-sh/ulid-go"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/x/bsonx/bsoncore"
+)
+
+// ULID wraps pulid.ULID implementing bson.ValueMarshaler and bson.ValueUnmarshaler
+type ULID pulid.ULID
+
+var tULID = reflect.TypeOf(pulid.ULID{})
+
+// MarshalBSONValue encodes the id as binary subtype 4
+func (id ULID) MarshalBSONValue() (byte, []byte, error) {
+	return byte(bson.TypeBinary), bsoncore.AppendBinary(nil, bson.TypeBinaryUUID, id[:]), nil
+}
+
+// UnmarshalBSONValue decodes a binary subtype 4 holding 16 bytes
+func (id *ULID) UnmarshalBSONValue(typ byte, data []byte) error {
+	if bson.Type(typ) != bson.TypeBinary {
+		return errors.
+			New("invalid bson type %s; expected binary", bson.Type(typ)).
+			WithErrorCode(pulid.InvalidSizeULIDSystemErrorCode).
+			WithNestedError(pulid.ErrInvalidSize)
+	}
+
+	subtype, raw, _, ok := bsoncore.ReadBinary(data)
+	if !ok {
+		return errors.
+			New("invalid bson binary").
+			WithErrorCode(pulid.InvalidSizeULIDSystemErrorCode).
+			WithNestedError(pulid.ErrInvalidSize)
+	}
+
+	if subtype != bson.TypeBinaryUUID {
+		return errors.
+			New("invalid bson binary subtype %d; expected %d", subtype, bson.TypeBinaryUUID).
+			WithErrorCode(pulid.InvalidSizeULIDSystemErrorCode).
+			WithNestedError(pulid.ErrInvalidSize)
+	}
+
+	return (*pulid.ULID)(id).UnmarshalBinary(raw)
+}
+
+// ULID returns the wrapped pulid.ULID
+func (id ULID) ULID() pulid.ULID {
+	return pulid.ULID(id)
+}
+
+// Register makes the registry encode and decode pulid.ULID values as ULID does,
+// so pulid.ULID struct fields need no wrapping
+func Register(reg *bson.Registry) {
+	reg.RegisterTypeEncoder(tULID, bson.ValueEncoderFunc(encodeValue))
+	reg.RegisterTypeDecoder(tULID, bson.ValueDecoderFunc(decodeValue))
+}
+
+// NewRegistry returns the default registry with pulid.ULID registered
+func NewRegistry() *bson.Registry {
+	reg := bson.NewRegistry()
+	Register(reg)
+
+	return reg
+}
+
+func encodeValue(_ bson.EncodeContext, vw bson.ValueWriter, v reflect.Value) error {
+	id := v.Interface().(pulid.ULID)
+	return vw.WriteBinaryWithSubtype(id[:], bson.TypeBinaryUUID)
+}
+
+func decodeValue(_ bson.DecodeContext, vr bson.ValueReader, v reflect.Value) error {
+	raw, subtype, err := vr.ReadBinary()
+	if err != nil {
+		return err
+	}
+
+	if subtype != bson.TypeBinaryUUID {
+		return errors.
+			New("invalid bson binary subtype %d; expected %d", subtype, bson.TypeBinaryUUID).
+			WithErrorCode(pulid.InvalidSizeULIDSystemErrorCode).
+			WithNestedError(pulid.ErrInvalidSize)
+	}
+
+	id, err := pulid.UnmarshalBytes(raw)
+	if err != nil {
+		return err
+	}
+
+	v.Set(reflect.ValueOf(id))
+	return nil
+}
@@ -0,0 +1,68 @@
+package pulidbson
+
+import (
+	"bytes"
+	"testing"
+
+	pulid "github.com/pixie-sh/ulid-go"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestBSONValueRoundTrip(t *testing.T) {
+	type doc struct {
+		ID ULID `bson:"_id"`
+	}
+
+	in := doc{ID: ULID(pulid.MustNewScoped(567))}
+
+	data, err := bson.Marshal(in)
+	if err != nil {
+		t.Fatalf("BSON marshalling failed: %v", err)
+	}
+
+	raw := bson.Raw(data).Lookup("_id")
+	subtype, value, ok := raw.BinaryOK()
+	if !ok || subtype != bson.TypeBinaryUUID || !bytes.Equal(value, in.ID[:]) {
+		t.Fatalf("Expected binary subtype 4, got %s", raw)
+	}
+
+	var out doc
+	if err = bson.Unmarshal(data, &out); err != nil {
+		t.Fatalf("BSON unmarshalling failed: %v", err)
+	}
+
+	if out.ID != in.ID {
+		t.Fatalf("BSON round trip failed: expected %v, got %v", in.ID.ULID(), out.ID.ULID())
+	}
+}
+
+func TestBSONRegistryRoundTrip(t *testing.T) {
+	type doc struct {
+		ID   pulid.ULID   `bson:"_id"`
+		Refs []pulid.ULID `bson:"refs"`
+	}
+
+	in := doc{ID: pulid.MustNewScoped(567), Refs: []pulid.ULID{pulid.MustNew()}}
+
+	buf := &bytes.Buffer{}
+	enc := bson.NewEncoder(bson.NewDocumentWriter(buf))
+	enc.SetRegistry(NewRegistry())
+	if err := enc.Encode(in); err != nil {
+		t.Fatalf("BSON marshalling failed: %v", err)
+	}
+
+	if subtype, _, ok := bson.Raw(buf.Bytes()).Lookup("_id").BinaryOK(); !ok || subtype != bson.TypeBinaryUUID {
+		t.Fatalf("Expected binary subtype 4")
+	}
+
+	var out doc
+	dec := bson.NewDecoder(bson.NewDocumentReader(bytes.NewReader(buf.Bytes())))
+	dec.SetRegistry(NewRegistry())
+	if err := dec.Decode(&out); err != nil {
+		t.Fatalf("BSON unmarshalling failed: %v", err)
+	}
+
+	if out.ID != in.ID || len(out.Refs) != 1 || out.Refs[0] != in.Refs[0] {
+		t.Fatalf("BSON round trip failed: expected %+v, got %+v", in, out)
+	}
+}
@@ -0,0 +1,18 @@
+module github.com/pixie-sh/ulid-go/pulidbson
+
+go 1.25.0
+
+require (
+	github.com/pixie-sh/errors-go v0.3.6
+	github.com/pixie-sh/ulid-go v0.2.0
+	go.mongodb.org/mongo-driver/v2 v2.9.1
+)
+
+require (
+	github.com/goccy/go-json v0.10.5 // indirect
+	github.com/mitchellh/mapstructure v1.5.0 // indirect
+	github.com/pixie-sh/logger-go v0.4.4 // indirect
+	golang.org/x/crypto v0.53.0 // indirect
+)
+
+replace github.com/mitchellh/mapstructure => github.com/rsnullptr/mapstructure v1.5.0
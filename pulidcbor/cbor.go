@@ -0,0 +1,80 @@
+// Package pulidcbor encodes pulid.ULID as a CBOR byte string holding the 16 bytes binary form.
+// pULIDs have no RFC 9562 version nor variant bits, so CBOR tag 37 (UUID) is opt-in, see TaggedEncMode.
+package pulidcbor
+
+import (
+	"reflect"
+
+	"github.com/fxamacker/cbor/v2"
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+// TagUUID CBOR tag number registered for UUIDs
+const TagUUID uint64 = 37
+
+// TagSet returns a cbor.TagSet with pulid.ULID registered as TagUUID
+func TagSet() (cbor.TagSet, error) {
+	tags := cbor.NewTagSet()
+
+	err := tags.Add(
+		cbor.TagOptions{EncTag: cbor.EncTagRequired, DecTag: cbor.DecTagRequired},
+		reflect.TypeOf(pulid.ULID{}),
+		TagUUID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// EncMode returns an encoding mode writing pulid.ULID as an untagged byte string
+func EncMode(opts ...cbor.EncOptions) (cbor.EncMode, error) {
+	var o cbor.EncOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	return o.EncMode()
+}
+
+// DecMode returns a decoding mode reading pulid.ULID from an untagged byte string
+func DecMode(opts ...cbor.DecOptions) (cbor.DecMode, error) {
+	var o cbor.DecOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	return o.DecMode()
+}
+
+// TaggedEncMode returns an encoding mode with pulid.ULID registered as TagUUID,
+// for peers expecting UUIDs; the bytes are not RFC 9562 compliant
+func TaggedEncMode(opts ...cbor.EncOptions) (cbor.EncMode, error) {
+	tags, err := TagSet()
+	if err != nil {
+		return nil, err
+	}
+
+	var o cbor.EncOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	return o.EncModeWithTags(tags)
+}
+
+// TaggedDecMode returns a decoding mode with pulid.ULID registered as TagUUID, requiring the tag
+func TaggedDecMode(opts ...cbor.DecOptions) (cbor.DecMode, error) {
+	tags, err := TagSet()
+	if err != nil {
+		return nil, err
+	}
+
+	var o cbor.DecOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	return o.DecModeWithTags(tags)
+}
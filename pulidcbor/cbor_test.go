@@ -0,0 +1,80 @@
+package pulidcbor
+
+import (
+	"bytes"
+	"testing"
+
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+func TestCBORRoundTrip(t *testing.T) {
+	em, err := EncMode()
+	if err != nil {
+		t.Fatalf("Failed to create encoding mode: %v", err)
+	}
+
+	dm, err := DecMode()
+	if err != nil {
+		t.Fatalf("Failed to create decoding mode: %v", err)
+	}
+
+	id := pulid.MustNewScoped(567)
+
+	data, err := em.Marshal(id)
+	if err != nil {
+		t.Fatalf("CBOR marshalling failed: %v", err)
+	}
+
+	// byte string of 16 (0x50), untagged
+	expected := append([]byte{0x50}, id[:]...)
+	if !bytes.Equal(data, expected) {
+		t.Fatalf("CBOR encoding mismatch: expected %X, got %X", expected, data)
+	}
+
+	var decoded pulid.ULID
+	if err = dm.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("CBOR unmarshalling failed: %v", err)
+	}
+
+	if decoded != id {
+		t.Fatalf("CBOR round trip failed: expected %v, got %v", id, decoded)
+	}
+
+	if err = dm.Unmarshal(append([]byte{0x4f}, id[:15]...), &decoded); err == nil {
+		t.Fatalf("Expected error decoding a 15 bytes string")
+	}
+}
+
+func TestCBORTagged(t *testing.T) {
+	em, err := TaggedEncMode()
+	if err != nil {
+		t.Fatalf("Failed to create encoding mode: %v", err)
+	}
+
+	dm, err := TaggedDecMode()
+	if err != nil {
+		t.Fatalf("Failed to create decoding mode: %v", err)
+	}
+
+	id := pulid.MustNewScoped(567)
+
+	data, err := em.Marshal(id)
+	if err != nil {
+		t.Fatalf("CBOR marshalling failed: %v", err)
+	}
+
+	// tag 37 (0xd8 0x25), byte string of 16 (0x50)
+	expected := append([]byte{0xd8, 0x25, 0x50}, id[:]...)
+	if !bytes.Equal(data, expected) {
+		t.Fatalf("CBOR encoding mismatch: expected %X, got %X", expected, data)
+	}
+
+	var decoded pulid.ULID
+	if err = dm.Unmarshal(data, &decoded); err != nil || decoded != id {
+		t.Fatalf("CBOR round trip failed: expected %v, got %v; err %v", id, decoded, err)
+	}
+
+	if err = dm.Unmarshal(append([]byte{0x50}, id[:]...), &decoded); err == nil {
+		t.Fatalf("Expected error decoding an untagged byte string")
+	}
+}
@@ -0,0 +1,23 @@
+module github.com/pixie-sh/ulid-go/pulidgrpc
+
+go 1.25.0
+
+require (
+	github.com/pixie-sh/ulid-go v0.2.0
+	google.golang.org/grpc v1.84.0
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	github.com/goccy/go-json v0.10.5 // indirect
+	github.com/mitchellh/mapstructure v1.5.0 // indirect
+	github.com/pixie-sh/errors-go v0.3.6 // indirect
+	github.com/pixie-sh/logger-go v0.4.4 // indirect
+	golang.org/x/crypto v0.54.0 // indirect
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260706201446-f0a921348800 // indirect
+)
+
+replace github.com/mitchellh/mapstructure => github.com/rsnullptr/mapstructure v1.5.0
@@ -0,0 +1,136 @@
+// Package pulidgrpc propagates request ids, as pulid.ULID, through gRPC metadata.
+//
+// Client interceptors send the id stored under pulid.RequestIDKey, or the x-request-id already
+// on the outgoing metadata, minting one when both are absent.
+// Server interceptors read it, validate its scope and store it under pulid.RequestIDKey,
+// minting one when absent, and echo it on the response header.
+package pulidgrpc
+
+import (
+	"context"
+	"slices"
+
+	pulid "github.com/pixie-sh/ulid-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataKey metadata key holding the request id
+const MetadataKey = "x-request-id"
+
+// UnaryServerInterceptor reads the request id from the incoming metadata, minting one with scope when absent.
+// Ids failing to parse, or whose scope is not on allowed, when set, fail with codes.InvalidArgument
+func UnaryServerInterceptor(scope pulid.Scope, allowed ...pulid.Scope) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := serverContext(ctx, scope, allowed)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor stream counterpart of UnaryServerInterceptor
+func StreamServerInterceptor(scope pulid.Scope, allowed ...pulid.Scope) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := serverContext(ss.Context(), scope, allowed)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// UnaryClientInterceptor sends the context request id, falling back to the one on the outgoing metadata
+// and minting one with scope when both are absent
+func UnaryClientInterceptor(scope pulid.Scope) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, err := clientContext(ctx, scope)
+		if err != nil {
+			return err
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor stream counterpart of UnaryClientInterceptor
+func StreamClientInterceptor(scope pulid.Scope) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, err := clientContext(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// FromContext returns the request id stored by the interceptors
+func FromContext(ctx context.Context) (pulid.ULID, bool) {
+	return pulid.FromContext(ctx, pulid.RequestIDKey)
+}
+
+func serverContext(ctx context.Context, scope pulid.Scope, allowed []pulid.Scope) (context.Context, error) {
+	var id pulid.ULID
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(MetadataKey); len(values) > 0 {
+		if err := id.UnmarshalText([]byte(values[0])); err != nil {
+			return ctx, status.Errorf(codes.InvalidArgument, "invalid %s: %v", MetadataKey, err)
+		}
+
+		idScope, _ := id.Scope()
+		if len(allowed) > 0 && !slices.Contains(allowed, idScope) {
+			return ctx, status.Errorf(codes.InvalidArgument, "invalid %s scope %d", MetadataKey, idScope)
+		}
+	} else {
+		var err error
+		if id, err = pulid.NewScoped(scope); err != nil {
+			return ctx, status.Errorf(codes.Internal, "unable to generate %s: %v", MetadataKey, err)
+		}
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(MetadataKey, id.String())); err != nil {
+		return ctx, err
+	}
+
+	return pulid.WithID(ctx, pulid.RequestIDKey, id), nil
+}
+
+// clientContext sets the request id on the outgoing metadata, replacing any value already there,
+// so servers always read a single id. The context id wins over a header set by the caller,
+// which in turn wins over minting a new id
+func clientContext(ctx context.Context, scope pulid.Scope) (context.Context, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+
+	id, ok := pulid.FromContext(ctx, pulid.RequestIDKey)
+	if !ok {
+		if values := md.Get(MetadataKey); len(values) > 0 {
+			md.Set(MetadataKey, values[0])
+			return metadata.NewOutgoingContext(ctx, md), nil
+		}
+
+		var err error
+		if id, err = pulid.NewScoped(scope); err != nil {
+			return ctx, err
+		}
+	}
+
+	md.Set(MetadataKey, id.String())
+	return metadata.NewOutgoingContext(ctx, md), nil
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
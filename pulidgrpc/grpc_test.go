@@ -0,0 +1,201 @@
+package pulidgrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	pulid "github.com/pixie-sh/ulid-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// seen receives the request ids observed by the test service handlers
+var seen = make(chan pulid.ULID, 1)
+
+var testServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pulid.test.Echo",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Unary",
+		Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := new(emptypb.Empty)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+
+			handler := func(ctx context.Context, _ any) (any, error) {
+				id, _ := FromContext(ctx)
+				seen <- id
+				return &emptypb.Empty{}, nil
+			}
+
+			return interceptor(ctx, in, &grpc.UnaryServerInfo{FullMethod: "/pulid.test.Echo/Unary"}, handler)
+		},
+	}},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Stream",
+		ServerStreams: true,
+		Handler: func(_ any, stream grpc.ServerStream) error {
+			if err := stream.RecvMsg(new(emptypb.Empty)); err != nil {
+				return err
+			}
+
+			id, _ := FromContext(stream.Context())
+			seen <- id
+			return stream.SendMsg(&emptypb.Empty{})
+		},
+	}},
+}
+
+func dial(t *testing.T, interceptors bool, allowed ...pulid.Scope) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(567, allowed...)),
+		grpc.StreamInterceptor(StreamServerInterceptor(567, allowed...)),
+	)
+	srv.RegisterService(&testServiceDesc, nil)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	opts := []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	if interceptors {
+		opts = append(opts,
+			grpc.WithUnaryInterceptor(UnaryClientInterceptor(42)),
+			grpc.WithStreamInterceptor(StreamClientInterceptor(42)),
+		)
+	}
+
+	conn, err := grpc.NewClient("passthrough:///bufnet", opts...)
+	if err != nil {
+		t.Fatalf("Failed to dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func TestUnaryInterceptors(t *testing.T) {
+	conn := dial(t, true)
+
+	id := pulid.MustNewScoped(42)
+	ctx := pulid.WithID(context.Background(), pulid.RequestIDKey, id)
+
+	var header metadata.MD
+	if err := conn.Invoke(ctx, "/pulid.test.Echo/Unary", &emptypb.Empty{}, &emptypb.Empty{}, grpc.Header(&header)); err != nil {
+		t.Fatalf("Unary call failed: %v", err)
+	}
+
+	if got := <-seen; got != id {
+		t.Fatalf("Expected server to see %v, got %v", id, got)
+	}
+
+	if echoed := header.Get(MetadataKey); len(echoed) != 1 || echoed[0] != id.String() {
+		t.Fatalf("Expected %v echoed on header, got %v", id, echoed)
+	}
+
+	// no id on context, the client interceptor mints one with its scope
+	if err := conn.Invoke(context.Background(), "/pulid.test.Echo/Unary", &emptypb.Empty{}, &emptypb.Empty{}); err != nil {
+		t.Fatalf("Unary call failed: %v", err)
+	}
+
+	if scope, _ := (<-seen).Scope(); scope != 42 {
+		t.Fatalf("Expected client minted id with scope 42, got %d", scope)
+	}
+}
+
+func TestStreamInterceptors(t *testing.T) {
+	conn := dial(t, true)
+
+	id := pulid.MustNewScoped(42)
+	ctx := pulid.WithID(context.Background(), pulid.RequestIDKey, id)
+
+	stream, err := conn.NewStream(ctx, &testServiceDesc.Streams[0], "/pulid.test.Echo/Stream")
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+
+	if err = stream.SendMsg(&emptypb.Empty{}); err != nil {
+		t.Fatalf("Failed to send: %v", err)
+	}
+
+	if err = stream.CloseSend(); err != nil {
+		t.Fatalf("Failed to close send: %v", err)
+	}
+
+	if err = stream.RecvMsg(new(emptypb.Empty)); err != nil {
+		t.Fatalf("Failed to receive: %v", err)
+	}
+
+	if got := <-seen; got != id {
+		t.Fatalf("Expected server to see %v, got %v", id, got)
+	}
+}
+
+func TestServerValidation(t *testing.T) {
+	conn := dial(t, false, 567)
+
+	invalid := []string{"not-an-id", pulid.MustNewScoped(42).String()}
+	for _, value := range invalid {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), MetadataKey, value)
+		err := conn.Invoke(ctx, "/pulid.test.Echo/Unary", &emptypb.Empty{}, &emptypb.Empty{})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("Expected InvalidArgument for %s, got %v", value, err)
+		}
+	}
+
+	// no id sent, the server mints one with its scope
+	if err := conn.Invoke(context.Background(), "/pulid.test.Echo/Unary", &emptypb.Empty{}, &emptypb.Empty{}); err != nil {
+		t.Fatalf("Unary call failed: %v", err)
+	}
+
+	if scope, _ := (<-seen).Scope(); scope != 567 {
+		t.Fatalf("Expected server minted id with scope 567, got %d", scope)
+	}
+}
+
+func TestClientExistingHeader(t *testing.T) {
+	conn := dial(t, true)
+
+	var (
+		id     = pulid.MustNewScoped(42)
+		header = pulid.MustNewScoped(43)
+		ctx    = metadata.AppendToOutgoingContext(context.Background(), MetadataKey, header.String())
+	)
+
+	// the context id replaces the header set by the caller, never adding a second value
+	out, err := clientContext(pulid.WithID(ctx, pulid.RequestIDKey, id), 42)
+	if err != nil {
+		t.Fatalf("Failed to set the outgoing id: %v", err)
+	}
+
+	md, _ := metadata.FromOutgoingContext(out)
+	if values := md.Get(MetadataKey); len(values) != 1 || values[0] != id.String() {
+		t.Fatalf("Expected the single value %v, got %v", id, values)
+	}
+
+	if err = conn.Invoke(pulid.WithID(ctx, pulid.RequestIDKey, id), "/pulid.test.Echo/Unary", &emptypb.Empty{}, &emptypb.Empty{}); err != nil {
+		t.Fatalf("Unary call failed: %v", err)
+	}
+
+	if got := <-seen; got != id {
+		t.Fatalf("Expected server to see the context id %v, got %v", id, got)
+	}
+
+	// without a context id, the header set by the caller is kept
+	if err = conn.Invoke(ctx, "/pulid.test.Echo/Unary", &emptypb.Empty{}, &emptypb.Empty{}); err != nil {
+		t.Fatalf("Unary call failed: %v", err)
+	}
+
+	if got := <-seen; got != header {
+		t.Fatalf("Expected server to see the caller header %v, got %v", header, got)
+	}
+}
@@ -0,0 +1,56 @@
+// Package pulidhttp propagates request ids, as pulid.ULID, through net/http handlers.
+package pulidhttp
+
+import (
+	"net/http"
+	"slices"
+
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+// RequestIDHeader header read and echoed by RequestID
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID returns a middleware that reads the request id from RequestIDHeader, as ULID or UUID text,
+// minting a new one with scope when the header is missing. Ids failing to parse, or whose scope is not
+// on allowed, when set, are rejected with 400 Bad Request, as pulidgrpc does with codes.InvalidArgument.
+// The id is stored under pulid.RequestIDKey and echoed on the response header as received, or as ULID text when minted
+func RequestID(scope pulid.Scope, allowed ...pulid.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var (
+				id     pulid.ULID
+				header = r.Header.Get(RequestIDHeader)
+			)
+
+			if header != "" {
+				if err := id.UnmarshalText([]byte(header)); err != nil {
+					http.Error(w, "invalid "+RequestIDHeader, http.StatusBadRequest)
+					return
+				}
+
+				idScope, _ := id.Scope()
+				if len(allowed) > 0 && !slices.Contains(allowed, idScope) {
+					http.Error(w, "invalid "+RequestIDHeader+" scope", http.StatusBadRequest)
+					return
+				}
+			} else {
+				var err error
+				if id, err = pulid.NewScoped(scope); err != nil {
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+					return
+				}
+
+				header = id.String()
+			}
+
+			w.Header().Set(RequestIDHeader, header)
+			next.ServeHTTP(w, r.WithContext(pulid.WithID(r.Context(), pulid.RequestIDKey, id)))
+		})
+	}
+}
+
+// FromRequest returns the request id stored by RequestID
+func FromRequest(r *http.Request) (pulid.ULID, bool) {
+	return pulid.FromContext(r.Context(), pulid.RequestIDKey)
+}
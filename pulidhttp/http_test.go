@@ -0,0 +1,73 @@
+package pulidhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+func TestRequestID(t *testing.T) {
+	var seen pulid.ULID
+	handler := RequestID(567)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		id, ok := FromRequest(r)
+		if !ok {
+			t.Fatalf("Expected request id on context")
+		}
+		seen = id
+	}))
+
+	serve := func(header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set(RequestIDHeader, header)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	incoming := pulid.MustNewScoped(42)
+	for _, header := range []string{incoming.String(), incoming.UUID()} {
+		rec := serve(header)
+		if seen != incoming || rec.Header().Get(RequestIDHeader) != header {
+			t.Fatalf("Expected incoming id %v kept and echoed as %s, got %v; echoed %s", incoming, header, seen, rec.Header().Get(RequestIDHeader))
+		}
+	}
+
+	rec := serve("")
+	if scope, _ := seen.Scope(); scope != 567 || seen == incoming {
+		t.Fatalf("Expected minted id with scope 567, got %v", seen)
+	}
+
+	if rec.Header().Get(RequestIDHeader) != seen.String() {
+		t.Fatalf("Expected minted id echoed, got %s", rec.Header().Get(RequestIDHeader))
+	}
+
+	if rec = serve("not-an-id"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a malformed id, got %d", rec.Code)
+	}
+}
+
+func TestRequestIDAllowedScopes(t *testing.T) {
+	handler := RequestID(567, 567)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	for header, code := range map[string]int{
+		pulid.MustNewScoped(567).String(): http.StatusOK,
+		pulid.MustNewScoped(42).String():  http.StatusBadRequest,
+		"":                                http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set(RequestIDHeader, header)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != code {
+			t.Fatalf("Expected %d for %q, got %d", code, header, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+// Package pulidlogger attaches pulid.ULID fields to github.com/pixie-sh/logger-go loggers,
+// expanded as in log/slog so registered scope names show up in the logs.
+package pulidlogger
+
+import (
+	"github.com/pixie-sh/logger-go/logger"
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+// With returns l with the id fields under key; verbosity defaults to pulid.DefaultLogVerbosity
+func With(l logger.Interface, key string, id pulid.ULID, verbosity ...pulid.LogVerbosity) logger.Interface {
+	return l.With(key, Value(id, verbosity...))
+}
+
+// Value returns the logger field value for id: the ULID text for pulid.LogVerbosityID,
+// otherwise a map with the slog group attributes
+func Value(id pulid.ULID, verbosity ...pulid.LogVerbosity) any {
+	v := pulid.DefaultLogVerbosity
+	if len(verbosity) > 0 {
+		v = verbosity[0]
+	}
+
+	if v == pulid.LogVerbosityID {
+		return id.String()
+	}
+
+	return id.LogFields(v)
+}
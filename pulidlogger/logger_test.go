@@ -0,0 +1,48 @@
+package pulidlogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pixie-sh/logger-go/logger"
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+func TestWith(t *testing.T) {
+	if err := pulid.RegisterScopeName(567, "usr"); err != nil {
+		t.Fatalf("Failed to register scope name: %v", err)
+	}
+
+	var buf bytes.Buffer
+	log, err := logger.NewLogger(context.Background(), &buf, "app", "test", "1", logger.DEBUG, nil, logger.DefaultJSONParser)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	id := pulid.MustNewScoped(567)
+	With(log, "user", id, pulid.LogVerbosityFull).Log("created")
+
+	var entry map[string]any
+	if err = json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Invalid log entry %s: %v", buf.String(), err)
+	}
+
+	fields, _ := entry["user"].(map[string]any)
+	if fields == nil {
+		t.Fatalf("Expected user fields in %s", buf.String())
+	}
+
+	if fields["id"] != id.String() || fields["scope_name"] != "usr" || fields["uuid"] != id.UUID() {
+		t.Fatalf("Unexpected user fields %+v", fields)
+	}
+
+	if fields, _ := Value(id).(map[string]any); fields["scope_name"] != "usr" {
+		t.Fatalf("Default verbosity should have the scope name, got %+v", fields)
+	}
+
+	if Value(id, pulid.LogVerbosityID) != id.String() {
+		t.Fatalf("LogVerbosityID should log the ULID text")
+	}
+}
@@ -0,0 +1,19 @@
+module github.com/pixie-sh/ulid-go/pulidmsgpack
+
+go 1.25.0
+
+require (
+	github.com/pixie-sh/errors-go v0.3.6
+	github.com/pixie-sh/ulid-go v0.2.0
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require (
+	github.com/goccy/go-json v0.10.5 // indirect
+	github.com/mitchellh/mapstructure v1.5.0 // indirect
+	github.com/pixie-sh/logger-go v0.4.4 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	golang.org/x/crypto v0.54.0 // indirect
+)
+
+replace github.com/mitchellh/mapstructure => github.com/rsnullptr/mapstructure v1.5.0
@@ -0,0 +1,39 @@
+// Package pulidmsgpack encodes pulid.ULID as a MessagePack extension type
+// holding the 16 bytes binary form.
+package pulidmsgpack
+
+import (
+	"reflect"
+
+	"github.com/pixie-sh/errors-go"
+	pulid "github.com/pixie-sh/ulid-go"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// DefaultExtID extension type used by Register when none is passed
+const DefaultExtID int8 = 16
+
+// Register registers pulid.ULID as the msgpack extension type extID, DefaultExtID if omitted.
+// Call it once, at init, before encoding or decoding
+func Register(extID ...int8) {
+	id := DefaultExtID
+	if len(extID) > 0 {
+		id = extID[0]
+	}
+
+	msgpack.RegisterExtEncoder(id, pulid.ULID{}, func(_ *msgpack.Encoder, v reflect.Value) ([]byte, error) {
+		return v.Interface().(pulid.ULID).MarshalBinary()
+	})
+
+	msgpack.RegisterExtDecoder(id, pulid.ULID{}, func(d *msgpack.Decoder, v reflect.Value, extLen int) error {
+		if extLen != len(pulid.EmptyUID) {
+			return errors.
+				New("invalid msgpack ext size %d", extLen).
+				WithErrorCode(pulid.InvalidSizeULIDSystemErrorCode).
+				WithNestedError(pulid.ErrInvalidSize)
+		}
+
+		id := v.Addr().Interface().(*pulid.ULID)
+		return d.ReadFull(id[:])
+	})
+}
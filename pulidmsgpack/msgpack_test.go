@@ -0,0 +1,39 @@
+package pulidmsgpack
+
+import (
+	"testing"
+
+	pulid "github.com/pixie-sh/ulid-go"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	Register()
+
+	type cacheValue struct {
+		ID   pulid.ULID
+		Refs []pulid.ULID
+	}
+
+	in := cacheValue{ID: pulid.MustNewScoped(567), Refs: []pulid.ULID{pulid.MustNew(), pulid.MustNew()}}
+
+	data, err := msgpack.Marshal(in)
+	if err != nil {
+		t.Fatalf("Msgpack marshalling failed: %v", err)
+	}
+
+	var out cacheValue
+	if err = msgpack.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Msgpack unmarshalling failed: %v", err)
+	}
+
+	if out.ID != in.ID || len(out.Refs) != 2 || out.Refs[0] != in.Refs[0] || out.Refs[1] != in.Refs[1] {
+		t.Fatalf("Msgpack round trip failed: expected %+v, got %+v", in, out)
+	}
+
+	// fixext 16: 0xd8, ext type, 16 bytes
+	single, _ := msgpack.Marshal(in.ID)
+	if len(single) != 18 || single[0] != 0xd8 || int8(single[1]) != DefaultExtID {
+		t.Fatalf("Expected fixext 16 with type %d, got %X", DefaultExtID, single)
+	}
+}
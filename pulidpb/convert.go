@@ -0,0 +1,33 @@
+// Package pulidpb holds the pulid.v1 Protocol Buffers messages and conversion helpers.
+//
+// ulid.pb.go is generated from proto/pulid/v1/ulid.proto:
+//
+//go:generate protoc -I ../proto --go_out=.. --go_opt=module=github.com/pixie-sh/ulid-go pulid/v1/ulid.proto
+package pulidpb
+
+import (
+	"github.com/pixie-sh/errors-go"
+	pulid "github.com/pixie-sh/ulid-go"
+)
+
+// ToProto returns the id as a pulid.v1.ULID message
+func ToProto(id pulid.ULID) *ULID {
+	return &ULID{Value: id[:]}
+}
+
+// FromProto returns the pulid.ULID held by the message; value must be 16 bytes long
+func FromProto(m *ULID) (pulid.ULID, error) {
+	if m == nil {
+		return pulid.EmptyUID, errors.
+			New("nil ULID message").
+			WithErrorCode(pulid.InvalidSizeULIDSystemErrorCode).
+			WithNestedError(pulid.ErrInvalidSize)
+	}
+
+	return pulid.UnmarshalBytes(m.GetValue())
+}
+
+// ULID returns the pulid.ULID held by the message; value must be 16 bytes long
+func (x *ULID) ULID() (pulid.ULID, error) {
+	return FromProto(x)
+}
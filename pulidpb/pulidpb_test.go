@@ -0,0 +1,130 @@
+package pulidpb
+
+import (
+	"testing"
+
+	pulid "github.com/pixie-sh/ulid-go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	id := pulid.MustNewScoped(567)
+
+	data, err := proto.Marshal(ToProto(id))
+	if err != nil {
+		t.Fatalf("Proto marshalling failed: %v", err)
+	}
+
+	var m ULID
+	if err = proto.Unmarshal(data, &m); err != nil {
+		t.Fatalf("Proto unmarshalling failed: %v", err)
+	}
+
+	decoded, err := FromProto(&m)
+	if err != nil || decoded != id {
+		t.Fatalf("Proto round trip failed: expected %v, got %v; err %+v", id, decoded, err)
+	}
+
+	if _, err = FromProto(&ULID{Value: []byte{0x01, 0x02}}); err == nil {
+		t.Fatalf("Expected error for value not 16 bytes long")
+	}
+
+	if _, err = FromProto(nil); err == nil {
+		t.Fatalf("Expected error for nil message")
+	}
+}
+
+func TestValidateScopeRules(t *testing.T) {
+	md := holderDescriptor(t)
+
+	valid := newHolder(md, pulid.MustNewScoped(567), pulid.MustNewScoped(1))
+	if err := Validate(valid); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+
+	invalid := map[string]proto.Message{
+		"scope not in":  newHolder(md, pulid.MustNewScoped(568)),
+		"missing owner": dynamicpb.NewMessage(md),
+		"invalid size":  newHolder(md, pulid.MustNewScoped(567), pulid.MustNewScoped(1)),
+	}
+
+	refs := md.Fields().ByName("refs")
+	list := invalid["invalid size"].ProtoReflect().Mutable(refs).List()
+	list.Append(protoreflect.ValueOfMessage((&ULID{Value: []byte{0x01}}).ProtoReflect()))
+
+	for name, msg := range invalid {
+		if err := Validate(msg); err == nil {
+			t.Fatalf("Expected validation error for %s", name)
+		}
+	}
+
+	rules := &FieldRules{Scope: &ScopeRules{Gte: proto.Uint32(10), Lte: proto.Uint32(20)}}
+	if err := ToProto(pulid.MustNewScoped(15)).Validate(rules); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+
+	if err := ToProto(pulid.MustNewScoped(21)).Validate(rules); err == nil {
+		t.Fatalf("Expected validation error for scope above lte")
+	}
+}
+
+// holderDescriptor builds, at runtime, the equivalent of:
+//
+//	message Holder {
+//	  pulid.v1.ULID owner = 1 [(pulid.v1.field) = {required: true, scope: {in: [567]}}];
+//	  repeated pulid.v1.ULID refs = 2;
+//	}
+func holderDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	ownerOpts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(ownerOpts, E_Field, &FieldRules{Required: true, Scope: &ScopeRules{In: []uint32{567}}})
+
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("pulid/v1/holder_test.proto"),
+		Package:    proto.String("pulid.v1.test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{File_pulid_v1_ulid_proto.Path()},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Holder"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("owner"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String(".pulid.v1.ULID"),
+				Options:  ownerOpts,
+			}, {
+				Name:     proto.String("refs"),
+				Number:   proto.Int32(2),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String(".pulid.v1.ULID"),
+			}},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("Failed to build test descriptor: %v", err)
+	}
+
+	return fd.Messages().ByName("Holder")
+}
+
+func newHolder(md protoreflect.MessageDescriptor, owner pulid.ULID, refs ...pulid.ULID) proto.Message {
+	m := dynamicpb.NewMessage(md)
+	m.Set(md.Fields().ByName("owner"), protoreflect.ValueOfMessage(ToProto(owner).ProtoReflect()))
+
+	list := m.Mutable(md.Fields().ByName("refs")).List()
+	for _, ref := range refs {
+		list.Append(protoreflect.ValueOfMessage(ToProto(ref).ProtoReflect()))
+	}
+
+	return m
+}
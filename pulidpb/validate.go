@@ -0,0 +1,143 @@
+package pulidpb
+
+import (
+	"slices"
+
+	"github.com/pixie-sh/errors-go"
+	pulid "github.com/pixie-sh/ulid-go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const ulidFullName protoreflect.FullName = "pulid.v1.ULID"
+
+// Validate walks msg, including nested, repeated and map values, checking every
+// pulid.v1.ULID field holds 16 bytes and complies with its (pulid.v1.field) rules
+func Validate(msg proto.Message) error {
+	if msg == nil {
+		return nil
+	}
+
+	return validateMessage(msg.ProtoReflect(), "")
+}
+
+// Validate checks the message holds 16 bytes and, when set, complies with the rules
+func (x *ULID) Validate(rules ...*FieldRules) error {
+	var r *FieldRules
+	if len(rules) > 0 {
+		r = rules[0]
+	}
+
+	return validateULID(x, r, "value")
+}
+
+func validateMessage(m protoreflect.Message, path string) error {
+	var err error
+
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len() && err == nil; i++ {
+		fd := fields.Get(i)
+		name := join(path, string(fd.Name()))
+
+		if fd.Message() == nil {
+			continue
+		}
+
+		rules, _ := proto.GetExtension(fd.Options(), E_Field).(*FieldRules)
+		isULID := fd.Message().FullName() == ulidFullName
+
+		switch {
+		case fd.IsList():
+			list := m.Get(fd).List()
+			for j := 0; j < list.Len() && err == nil; j++ {
+				err = validateValue(list.Get(j).Message(), isULID, rules, name)
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				continue
+			}
+
+			isULID = fd.MapValue().Message().FullName() == ulidFullName
+			m.Get(fd).Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				err = validateValue(v.Message(), isULID, rules, name)
+				return err == nil
+			})
+		case !m.Has(fd):
+			if isULID && rules.GetRequired() {
+				err = errors.
+					New("%s is required", name).
+					WithErrorCode(pulid.InvalidSizeULIDSystemErrorCode).
+					WithNestedError(pulid.ErrInvalidSize)
+			}
+		default:
+			err = validateValue(m.Get(fd).Message(), isULID, rules, name)
+		}
+	}
+
+	return err
+}
+
+func validateValue(m protoreflect.Message, isULID bool, rules *FieldRules, path string) error {
+	if !isULID {
+		return validateMessage(m, path)
+	}
+
+	id, ok := m.Interface().(*ULID)
+	if !ok {
+		// dynamic messages sharing the pulid.v1.ULID descriptor
+		id = &ULID{Value: m.Get(m.Descriptor().Fields().ByNumber(1)).Bytes()}
+	}
+
+	return validateULID(id, rules, path)
+}
+
+func validateULID(x *ULID, rules *FieldRules, path string) error {
+	id, err := FromProto(x)
+	if err != nil {
+		return errors.
+			New("%s must hold 16 bytes", path).
+			WithErrorCode(pulid.InvalidSizeULIDSystemErrorCode).
+			WithNestedError(pulid.ErrInvalidSize, err)
+	}
+
+	sr := rules.GetScope()
+	if sr == nil {
+		return nil
+	}
+
+	scope, _ := id.Scope()
+	value := uint32(scope)
+
+	switch {
+	case len(sr.GetIn()) > 0 && !slices.Contains(sr.GetIn(), value):
+		return errors.
+			New("%s scope %d must be in %v", path, value, sr.GetIn()).
+			WithErrorCode(pulid.InvalidScopeULIDSystemErrorCode).
+			WithNestedError(pulid.ErrInvalidScope)
+	case slices.Contains(sr.GetNotIn(), value):
+		return errors.
+			New("%s scope %d must not be in %v", path, value, sr.GetNotIn()).
+			WithErrorCode(pulid.InvalidScopeULIDSystemErrorCode).
+			WithNestedError(pulid.ErrInvalidScope)
+	case sr.Gte != nil && value < sr.GetGte():
+		return errors.
+			New("%s scope %d must be greater or equal to %d", path, value, sr.GetGte()).
+			WithErrorCode(pulid.InvalidScopeULIDSystemErrorCode).
+			WithNestedError(pulid.ErrInvalidScope)
+	case sr.Lte != nil && value > sr.GetLte():
+		return errors.
+			New("%s scope %d must be less or equal to %d", path, value, sr.GetLte()).
+			WithErrorCode(pulid.InvalidScopeULIDSystemErrorCode).
+			WithNestedError(pulid.ErrInvalidScope)
+	}
+
+	return nil
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
@@ -0,0 +1,358 @@
+package pulid
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pixie-sh/errors-go"
+)
+
+// ScopeField named bit-field inside a Scope
+type ScopeField struct {
+	Name string
+	Bits uint8
+}
+
+// ScopeValues decoded ScopeSchema fields, by name
+type ScopeValues map[string]uint16
+
+// ScopeSchema declares named bit-fields inside a Scope, e.g. entity type (10 bits) and region (6 bits).
+// The first field is the most significant; fields are right aligned, so a schema
+// fits any layout with at least as many scope bits
+type ScopeSchema struct {
+	fields []ScopeField
+	bits   uint8
+}
+
+// NewScopeSchema returns a ScopeSchema with the input fields, in order
+func NewScopeSchema(fields ...ScopeField) (ScopeSchema, error) {
+	var (
+		schema = ScopeSchema{fields: make([]ScopeField, 0, len(fields))}
+		names  = make(map[string]struct{}, len(fields))
+		total  uint
+	)
+
+	for _, field := range fields {
+		if field.Name == "" || field.Bits == 0 {
+			return ScopeSchema{}, errors.
+				New("invalid scope field %+v; name and bits are required", field).
+				WithErrorCode(InvalidScopeULIDSystemErrorCode).
+				WithNestedError(ErrInvalidScope)
+		}
+
+		if _, ok := names[field.Name]; ok {
+			return ScopeSchema{}, errors.
+				New("duplicated scope field %s", field.Name).
+				WithErrorCode(InvalidScopeULIDSystemErrorCode).
+				WithNestedError(ErrInvalidScope)
+		}
+
+		names[field.Name] = struct{}{}
+		total += uint(field.Bits)
+		schema.fields = append(schema.fields, field)
+	}
+
+	if total > maxScopeBits {
+		return ScopeSchema{}, errors.
+			New("scope fields sum %d bits; max %d", total, maxScopeBits).
+			WithErrorCode(InvalidScopeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidScope)
+	}
+
+	schema.bits = uint8(total)
+	return schema, nil
+}
+
+func MustNewScopeSchema(fields ...ScopeField) ScopeSchema {
+	schema, err := NewScopeSchema(fields...)
+	if err != nil {
+		panic(err)
+	}
+
+	return schema
+}
+
+// Fields returns a copy of the schema fields
+func (s ScopeSchema) Fields() []ScopeField {
+	return append([]ScopeField(nil), s.fields...)
+}
+
+// Pack encodes the values into a Scope. values may be a map with string keys and
+// integer values, or a struct (or pointer to) whose fields match the schema by
+// `scope:"name"` tag or by field name. Missing fields are packed as 0.
+// Reserved scopes, ZeroedScopeValue and MaxScopeValue, are rejected
+func (s ScopeSchema) Pack(values any) (Scope, error) {
+	named, err := s.collect(values)
+	if err != nil {
+		return ZeroedScopeValue, err
+	}
+
+	var scope Scope
+	for _, field := range s.fields {
+		v := named[field.Name]
+		if v > bitMask(field.Bits) {
+			return ZeroedScopeValue, errors.
+				New("scope field %s overflow; max %d < input %d", field.Name, bitMask(field.Bits), v).
+				WithErrorCode(InvalidScopeULIDSystemErrorCode).
+				WithNestedError(ErrInvalidScope)
+		}
+
+		scope = scope<<field.Bits | Scope(v)
+	}
+
+	if scope == ZeroedScopeValue || scope == MaxScopeValue {
+		return ZeroedScopeValue, errors.
+			New("packed scope %d is reserved", scope).
+			WithErrorCode(InvalidScopeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidScope)
+	}
+
+	return scope, nil
+}
+
+// Unpack decodes the scope into the schema fields
+func (s ScopeSchema) Unpack(scope Scope) ScopeValues {
+	var (
+		values = make(ScopeValues, len(s.fields))
+		shift  = s.bits
+	)
+
+	for _, field := range s.fields {
+		shift -= field.Bits
+		values[field.Name] = uint16(uint64(scope>>shift) & bitMask(field.Bits))
+	}
+
+	return values
+}
+
+// UnpackInto decodes the scope into the struct pointed by dst, matching fields as Pack
+func (s ScopeSchema) UnpackInto(scope Scope, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.
+			New("unpack destination must be a non nil struct pointer").
+			WithErrorCode(InvalidScopeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidScope)
+	}
+
+	values := s.Unpack(scope)
+	rv = rv.Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		v, ok := values[scopeFieldName(rv.Type().Field(i))]
+		if !ok || !rv.Field(i).CanSet() {
+			continue
+		}
+
+		switch rv.Field(i).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if rv.Field(i).OverflowInt(int64(v)) {
+				return errors.
+					New("scope field %s overflows %s", rv.Type().Field(i).Name, rv.Field(i).Type()).
+					WithErrorCode(InvalidScopeULIDSystemErrorCode).
+					WithNestedError(ErrInvalidScope)
+			}
+			rv.Field(i).SetInt(int64(v))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if rv.Field(i).OverflowUint(uint64(v)) {
+				return errors.
+					New("scope field %s overflows %s", rv.Type().Field(i).Name, rv.Field(i).Type()).
+					WithErrorCode(InvalidScopeULIDSystemErrorCode).
+					WithNestedError(ErrInvalidScope)
+			}
+			rv.Field(i).SetUint(uint64(v))
+		}
+	}
+
+	return nil
+}
+
+// format writes the decoded scope as {name=value,...}, in schema order
+func (v ScopeValues) format(schema ScopeSchema) string {
+	b := strings.Builder{}
+	b.WriteByte('{')
+	for i, field := range schema.fields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(field.Name)
+		b.WriteByte('=')
+		b.WriteString(strconv.FormatUint(uint64(v[field.Name]), 10))
+	}
+	b.WriteByte('}')
+
+	return b.String()
+}
+
+// collect reads the values input as integers by schema field name
+func (s ScopeSchema) collect(values any) (map[string]uint64, error) {
+	var (
+		named = make(map[string]uint64, len(s.fields))
+		known = make(map[string]struct{}, len(s.fields))
+		rv    = reflect.ValueOf(values)
+	)
+
+	for _, field := range s.fields {
+		known[field.Name] = struct{}{}
+	}
+
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	set := func(name string, v reflect.Value) error {
+		if _, ok := known[name]; !ok {
+			return errors.
+				New("unknown scope field %s", name).
+				WithErrorCode(InvalidScopeULIDSystemErrorCode).
+				WithNestedError(ErrInvalidScope)
+		}
+
+		n, err := scopeFieldValue(name, v)
+		if err != nil {
+			return err
+		}
+
+		named[name] = n
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, errors.
+				New("scope values map keys must be strings").
+				WithErrorCode(InvalidScopeULIDSystemErrorCode).
+				WithNestedError(ErrInvalidScope)
+		}
+
+		iter := rv.MapRange()
+		for iter.Next() {
+			if err := set(iter.Key().String(), iter.Value()); err != nil {
+				return nil, err
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			sf := rv.Type().Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+
+			name := scopeFieldName(sf)
+			if _, ok := known[name]; !ok {
+				continue
+			}
+
+			if err := set(name, rv.Field(i)); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, errors.
+			New("scope values must be a map or a struct, got %T", values).
+			WithErrorCode(InvalidScopeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidScope)
+	}
+
+	return named, nil
+}
+
+func scopeFieldName(sf reflect.StructField) string {
+	if tag, ok := sf.Tag.Lookup("scope"); ok && tag != "" {
+		return tag
+	}
+
+	return sf.Name
+}
+
+func scopeFieldValue(name string, v reflect.Value) (uint64, error) {
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 {
+			return 0, errors.
+				New("scope field %s is negative", name).
+				WithErrorCode(InvalidScopeULIDSystemErrorCode).
+				WithNestedError(ErrInvalidScope)
+		}
+		return uint64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), nil
+	default:
+		return 0, errors.
+			New("scope field %s must be an integer, got %s", name, v.Kind()).
+			WithErrorCode(InvalidScopeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidScope)
+	}
+}
+
+var scopeNames = struct {
+	sync.RWMutex
+	byScope map[Scope]string
+	byName  map[string]Scope
+}{
+	byScope: make(map[Scope]string),
+	byName:  make(map[string]Scope),
+}
+
+// RegisterScopeName names a scope, e.g. 567 as "usr". Names are used as prefix by
+// JSONFormatPrefixed and shown on logs; they must be unique and made of
+// letters, digits or '-'
+func RegisterScopeName(scope Scope, name string) error {
+	if scope == ZeroedScopeValue || scope == MaxScopeValue {
+		return errors.
+			New("scope %d is reserved", scope).
+			WithErrorCode(InvalidScopeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidScope)
+	}
+
+	if name == "" || strings.IndexFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-')
+	}) >= 0 {
+		return errors.
+			New("invalid scope name '%s'", name).
+			WithErrorCode(InvalidScopeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidScope)
+	}
+
+	scopeNames.Lock()
+	defer scopeNames.Unlock()
+
+	if registered, ok := scopeNames.byName[name]; ok && registered != scope {
+		return errors.
+			New("scope name '%s' already registered for scope %d", name, registered).
+			WithErrorCode(InvalidScopeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidScope)
+	}
+
+	if previous, ok := scopeNames.byScope[scope]; ok {
+		delete(scopeNames.byName, previous)
+	}
+
+	scopeNames.byScope[scope] = name
+	scopeNames.byName[name] = scope
+	return nil
+}
+
+// ScopeName returns the name registered for the scope
+func ScopeName(scope Scope) (string, bool) {
+	scopeNames.RLock()
+	defer scopeNames.RUnlock()
+
+	name, ok := scopeNames.byScope[scope]
+	return name, ok
+}
+
+// ScopeByName returns the scope registered with name
+func ScopeByName(name string) (Scope, bool) {
+	scopeNames.RLock()
+	defer scopeNames.RUnlock()
+
+	scope, ok := scopeNames.byName[name]
+	return scope, ok
+}
@@ -0,0 +1,82 @@
+package pulid
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var entityRegionSchema = MustNewScopeSchema(
+	ScopeField{Name: "entity", Bits: 10},
+	ScopeField{Name: "region", Bits: 6},
+)
+
+func TestScopeSchemaPackUnpack(t *testing.T) {
+	scope, err := entityRegionSchema.Pack(map[string]int{"entity": 8, "region": 55})
+	if err != nil {
+		t.Fatalf("Failed to pack scope: %v", err)
+	}
+
+	if scope != 8<<6|55 {
+		t.Fatalf("Packed scope is incorrect, expected %d got %d", 8<<6|55, scope)
+	}
+
+	values := entityRegionSchema.Unpack(scope)
+	if values["entity"] != 8 || values["region"] != 55 {
+		t.Fatalf("Unpacked scope is incorrect: %+v", values)
+	}
+
+	type entityRegion struct {
+		Entity uint16 `scope:"entity"`
+		Region int    `scope:"region"`
+	}
+
+	structScope, err := entityRegionSchema.Pack(entityRegion{Entity: 8, Region: 55})
+	if err != nil || structScope != scope {
+		t.Fatalf("Struct packed scope is incorrect, expected %d got %d; err %+v", scope, structScope, err)
+	}
+
+	var decoded entityRegion
+	if err = entityRegionSchema.UnpackInto(scope, &decoded); err != nil {
+		t.Fatalf("Failed to unpack scope into struct: %v", err)
+	}
+
+	if decoded.Entity != 8 || decoded.Region != 55 {
+		t.Fatalf("Unpacked struct is incorrect: %+v", decoded)
+	}
+}
+
+func TestScopeSchemaValidation(t *testing.T) {
+	if _, err := NewScopeSchema(ScopeField{Name: "entity", Bits: 10}, ScopeField{Name: "region", Bits: 7}); err == nil {
+		t.Fatalf("Expected error for fields overflowing the scope bits")
+	}
+
+	if _, err := NewScopeSchema(ScopeField{Name: "entity", Bits: 8}, ScopeField{Name: "entity", Bits: 8}); err == nil {
+		t.Fatalf("Expected error for duplicated fields")
+	}
+
+	invalid := []any{
+		map[string]int{"entity": 1024, "region": 1},
+		map[string]int{"entity": -1, "region": 1},
+		map[string]int{"entity": 1, "unknown": 1},
+		map[string]int{"entity": 0, "region": 0},
+		map[string]int{"entity": 1023, "region": 63},
+		"entity=1",
+	}
+
+	for _, values := range invalid {
+		if _, err := entityRegionSchema.Pack(values); err == nil {
+			t.Fatalf("Expected error packing %+v", values)
+		}
+	}
+}
+
+func TestScopeSchemaFormat(t *testing.T) {
+	defer func(schema ScopeSchema) { DefaultScopeSchema = schema }(DefaultScopeSchema)
+	DefaultScopeSchema = entityRegionSchema
+
+	id := MustNewScoped(8<<6 | 55)
+	if out := fmt.Sprintf("%v", id); !strings.HasSuffix(out, ";scope=567{entity=8,region=55})") {
+		t.Fatalf("Format should show decoded scope fields, got %s", out)
+	}
+}
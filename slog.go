@@ -0,0 +1,93 @@
+package pulid
+
+import (
+	"log/slog"
+	"time"
+)
+
+// LogVerbosity selects the attributes a ULID expands to in structured logs
+type LogVerbosity uint8
+
+const (
+	// LogVerbosityID the ULID text only
+	LogVerbosityID LogVerbosity = iota
+	// LogVerbosityGroup group with id, time, scope and, when registered, the scope name
+	LogVerbosityGroup
+	// LogVerbosityFull LogVerbosityGroup plus the DefaultScopeSchema fields and uuid
+	LogVerbosityFull
+)
+
+// LogValue implements slog.LogValuer, expanding the id according to DefaultLogVerbosity
+func (id ULID) LogValue() slog.Value {
+	return id.LogValueWith(DefaultLogVerbosity)
+}
+
+// LogValueWith returns the id slog.Value for the input verbosity
+func (id ULID) LogValueWith(verbosity LogVerbosity) slog.Value {
+	if verbosity == LogVerbosityID {
+		return slog.StringValue(id.String())
+	}
+
+	scope, _ := id.Scope()
+	attrs := make([]slog.Attr, 0, 6)
+	attrs = append(attrs,
+		slog.String("id", id.String()),
+		slog.Time("time", id.Time().UTC()),
+		slog.Int("scope", int(scope)),
+	)
+
+	if name, ok := ScopeName(scope); ok {
+		attrs = append(attrs, slog.String("scope_name", name))
+	}
+
+	if verbosity >= LogVerbosityFull {
+		if len(DefaultScopeSchema.fields) > 0 {
+			values := DefaultScopeSchema.Unpack(scope)
+			fields := make([]any, 0, len(values))
+			for _, field := range DefaultScopeSchema.fields {
+				fields = append(fields, slog.Int(field.Name, int(values[field.Name])))
+			}
+
+			attrs = append(attrs, slog.Group("scope_fields", fields...))
+		}
+
+		attrs = append(attrs, slog.String("uuid", id.UUID()))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// LogFields returns the LogValueWith attributes as a map, for loggers not built on slog
+func (id ULID) LogFields(verbosity LogVerbosity) map[string]any {
+	value := id.LogValueWith(verbosity)
+	if value.Kind() != slog.KindGroup {
+		return map[string]any{"id": value.String()}
+	}
+
+	return groupToMap(value.Group())
+}
+
+// Attr returns a slog attribute for id; verbosity defaults to DefaultLogVerbosity
+func Attr(key string, id ULID, verbosity ...LogVerbosity) slog.Attr {
+	if len(verbosity) > 0 {
+		return slog.Attr{Key: key, Value: id.LogValueWith(verbosity[0])}
+	}
+
+	return slog.Any(key, id)
+}
+
+func groupToMap(attrs []slog.Attr) map[string]any {
+	out := make(map[string]any, len(attrs))
+	for _, attr := range attrs {
+		switch attr.Value.Kind() {
+		case slog.KindGroup:
+			out[attr.Key] = groupToMap(attr.Value.Group())
+		case slog.KindTime:
+			out[attr.Key] = attr.Value.Time().Format(time.RFC3339Nano)
+		default:
+			out[attr.Key] = attr.Value.Any()
+		}
+	}
+
+	return out
+}
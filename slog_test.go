@@ -0,0 +1,63 @@
+package pulid
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestULIDLogValue(t *testing.T) {
+	defer func(v LogVerbosity) { DefaultLogVerbosity = v }(DefaultLogVerbosity)
+
+	if err := RegisterScopeName(567, "usr"); err != nil {
+		t.Fatalf("Failed to register scope name: %v", err)
+	}
+
+	var (
+		buf = bytes.Buffer{}
+		log = slog.New(slog.NewJSONHandler(&buf, nil))
+		id  = MustNewScoped(567)
+	)
+
+	decode := func() map[string]any {
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("Invalid log entry %s: %v", buf.String(), err)
+		}
+		buf.Reset()
+
+		return entry
+	}
+
+	log.Info("group", "user", id)
+	group, ok := decode()["user"].(map[string]any)
+	if !ok || group["id"] != id.String() || group["scope"] != float64(567) || group["time"] == nil {
+		t.Fatalf("Expected id, time and scope group, got %+v", group)
+	}
+
+	if group["scope_name"] != "usr" {
+		t.Fatalf("Group verbosity should have the registered scope name, got %+v", group)
+	}
+
+	if _, ok = group["uuid"]; ok {
+		t.Fatalf("Group verbosity should not have the uuid, got %+v", group)
+	}
+
+	log.Info("unnamed", "user", MustNewScoped(568))
+	if unnamed := decode()["user"].(map[string]any); unnamed["scope_name"] != nil {
+		t.Fatalf("Unregistered scopes should not have a scope name, got %+v", unnamed)
+	}
+
+	log.Info("full", Attr("user", id, LogVerbosityFull))
+	full := decode()["user"].(map[string]any)
+	if full["scope_name"] != "usr" || full["uuid"] != id.UUID() {
+		t.Fatalf("Expected scope name and uuid, got %+v", full)
+	}
+
+	DefaultLogVerbosity = LogVerbosityID
+	log.Info("id", "user", id)
+	if entry := decode(); entry["user"] != id.String() {
+		t.Fatalf("Expected plain id, got %+v", entry["user"])
+	}
+}
@@ -0,0 +1,63 @@
+package pulid
+
+// Text the ULID text form held in an array; comparable, so usable as a map key,
+// and built without allocations. Keep it around instead of calling String repeatedly
+type Text [textEncodedSize]byte
+
+// Text returns the id text form
+func (id ULID) Text() Text {
+	var t Text
+	id.appendText(t[:0], encoding)
+	return t
+}
+
+// ParseText parses a ULID text form, either case, normalizing it to uppercase
+func ParseText(s string) (Text, error) {
+	return parseText(s)
+}
+
+func parseText[T ~string | ~[]byte](s T) (Text, error) {
+	var id ULID
+
+	if len(s) != textEncodedSize {
+		return Text{}, invalidLengthError(parseExpectedULID, s, textEncodedSize)
+	}
+
+	if !decodeText(&id, s) {
+		return Text{}, textError(s)
+	}
+
+	return id.Text(), nil
+}
+
+// String returns the text form as a string, allocating it
+func (t Text) String() string {
+	return string(t[:])
+}
+
+// ULID decodes the text form back; fails only for a Text not built by ULID.Text or ParseText, e.g. the zero value
+func (t Text) ULID() (ULID, error) {
+	var id ULID
+
+	if !decodeText(&id, t[:]) {
+		return EmptyUID, textError(t[:])
+	}
+
+	return id, nil
+}
+
+// MarshalText implements encoding.TextMarshaler, so Text map keys encode as JSON object keys
+func (t Text) MarshalText() ([]byte, error) {
+	return t[:], nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting what ParseText does
+func (t *Text) UnmarshalText(v []byte) error {
+	parsed, err := parseText(v)
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
@@ -0,0 +1,81 @@
+package pulid
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTextMapKey(t *testing.T) {
+	id := MustNewScoped(567)
+	text := id.Text()
+
+	if text.String() != id.String() {
+		t.Fatalf("Expected text %s, got %s", id.String(), text.String())
+	}
+
+	copied, err := UnmarshalString(id.String())
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", id, err)
+	}
+
+	seen := map[Text]ULID{text: id}
+	if got, ok := seen[copied.Text()]; !ok || got != id {
+		t.Fatalf("Expected map lookup to find %v, got %v (%t)", id, got, ok)
+	}
+
+	parsed, err := ParseText(strings.ToLower(id.String()))
+	if err != nil || parsed != text {
+		t.Fatalf("Expected lowercase text to normalize to %s, got %s: %v", text, parsed, err)
+	}
+
+	back, err := text.ULID()
+	if err != nil || back != id {
+		t.Fatalf("Expected %v back from text, got %v: %v", id, back, err)
+	}
+
+	if _, err = (Text{}).ULID(); !errors.Is(err, ErrInvalidChars) {
+		t.Fatalf("Expected invalid chars for the zero Text, got %v", err)
+	}
+
+	if _, err = ParseText("81JJN1AD5B08VJ5SRBJAWCBWDQ"); !errors.Is(err, ErrInvalidSize) {
+		t.Fatalf("Expected overflow error, got %v", err)
+	}
+
+	if _, err = ParseText(id.UUID()); !errors.Is(err, ErrInvalidSize) {
+		t.Fatalf("Expected UUIDs to be rejected, got %v", err)
+	}
+
+	raw, err := json.Marshal(seen)
+	if err != nil {
+		t.Fatalf("Failed to marshal map: %v", err)
+	}
+
+	decoded := map[Text]ULID{}
+	if err = json.Unmarshal(raw, &decoded); err != nil || decoded[text] != id {
+		t.Fatalf("Expected JSON map round trip, got %v: %v (%s)", decoded, err, raw)
+	}
+}
+
+func TestStringAccessorAllocations(t *testing.T) {
+	id := MustNew()
+
+	for name, fn := range map[string]func(){
+		"String":     func() { _ = id.String() },
+		"UUID":       func() { _ = id.UUID() },
+		"EncodeUUID": func() { _ = id.EncodeUUID() },
+	} {
+		if allocs := testing.AllocsPerRun(100, fn); allocs > 1 {
+			t.Fatalf("Expected %s to allocate only its string, got %v allocations", name, allocs)
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { _ = id.Text() }); allocs != 0 {
+		t.Fatalf("Expected Text not to allocate, got %v allocations", allocs)
+	}
+
+	if id.EncodeUUID() != strings.ReplaceAll(id.UUID(), "-", "") {
+		t.Fatalf("Expected hyphenless UUID, got %s", id.EncodeUUID())
+	}
+}
@@ -7,8 +7,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"slices"
 	"time"
-	"unsafe"
 
 	"github.com/pixie-sh/errors-go"
 )
@@ -23,47 +23,63 @@ import (
 type ULID [16]byte
 type Scope = uint16
 
-// New returns a ULID where the 7th and 8th bytes are filled with MaxScopeValue value
+// New returns a ULID with the DefaultLayout scope field filled with the layout max scope
 func New(customEntropy ...io.Reader) (ULID, error) {
-	return NewScoped(MaxScopeValue, customEntropy...)
+	return NewScoped(ZeroedScopeValue, customEntropy...)
 }
 
-// NewScoped returns a ULID where the 7th and 8th bytes are filled with input scope
-// or with MaxScopeValue value if ZeroedScopeValue is passed
+// NewScoped returns a ULID with the DefaultLayout scope field filled with input scope,
+// or with the layout max scope if ZeroedScopeValue is passed; MaxScopeValue on the default 16 bits.
+// Fields are placed according to DefaultLayout. When it has sub millisecond or counter bits, e.g. SubMillisLayout,
+// ids come from a process wide Generator and keep ordered as Generator.NewScoped ones, even across goroutines
 func NewScoped(scope Scope, customEntropy ...io.Reader) (ULID, error) {
-	var (
-		id      = EmptyUID
-		now     = time.Now()
-		entropy = defaultEntropy
-		err     error
-	)
-
-	if scope > MaxScopeValue {
-		return EmptyUID, errors.
-			New("scope value overflow; max %d < input %d", MaxScopeValue, scope).
-			WithErrorCode(InvalidScopeULIDSystemErrorCode)
-	}
+	var entropy = defaultEntropy
 
 	if len(customEntropy) > 0 && customEntropy[0] != nil {
 		entropy = customEntropy[0]
 	}
 
-	if scope == ZeroedScopeValue {
-		scope = MaxScopeValue
+	if !DefaultLayout.ordered() {
+		return NewScopedAt(time.Now(), scope, entropy)
 	}
 
-	//7th and 8th reserved for scoping the ulid
-	binary.BigEndian.PutUint16(id[6:8], scope)
+	g, err := processGenerator()
+	if err != nil {
+		return EmptyUID, err
+	}
 
-	if _, err = entropy.Read(id[8:]); err != nil {
-		return id, err
+	return g.newScoped(scope, entropy)
+}
+
+// NewScopedAt is NewScoped with the timestamp set to t, e.g. to backfill or test with fixed times.
+// DefaultLayout is validated on every call, an invalid one is returned as error
+func NewScopedAt(t time.Time, scope Scope, customEntropy ...io.Reader) (ULID, error) {
+	var entropy = defaultEntropy
+
+	if len(customEntropy) > 0 && customEntropy[0] != nil {
+		entropy = customEntropy[0]
 	}
 
-	if err = id.setTime(now); err != nil {
-		return id, err
+	if err := DefaultLayout.Validate(); err != nil {
+		return EmptyUID, err
 	}
 
-	return id, nil
+	ticks, err := DefaultLayout.ticks(t)
+	if err != nil {
+		return EmptyUID, err
+	}
+
+	return DefaultLayout.newID(ticks, scope, 0, 0, entropy)
+}
+
+// MinAt returns the smallest DefaultLayout id on the millisecond of t, whatever its scope
+func MinAt(t time.Time) (ULID, error) {
+	return DefaultLayout.MinAt(t)
+}
+
+// MaxAt returns the largest DefaultLayout id on the millisecond of t, whatever its scope
+func MaxAt(t time.Time) (ULID, error) {
+	return DefaultLayout.MaxAt(t)
 }
 
 func MustNew(customEntropy ...io.Reader) ULID {
@@ -94,6 +110,20 @@ func UnmarshalString(s string) (ULID, error) {
 	return id, nil
 }
 
+// UnmarshalStringWithLayout parses s and verifies its timestamp matches the layout epoch configuration
+func UnmarshalStringWithLayout(s string, layout Layout) (ULID, error) {
+	id, err := UnmarshalString(s)
+	if err != nil {
+		return EmptyUID, err
+	}
+
+	if err = id.VerifyEpoch(layout); err != nil {
+		return EmptyUID, err
+	}
+
+	return id, nil
+}
+
 func UnmarshalBytes(b []byte) (ULID, error) {
 	id := ULID{}
 
@@ -129,39 +159,9 @@ func UnmarshalUint64(num uint64) (ULID, error) {
 	return id, nil
 }
 
+// UnmarshalUUID parses a UUID string in the forms enabled by DefaultParseOptions
 func UnmarshalUUID(s string) (ULID, error) {
-	id := ULID{}
-
-	if len(s) != uuidStringLength {
-		return id, errors.New("invalid data size len(%d)", len(s)).WithErrorCode(InvalidSizeULIDSystemErrorCode)
-	}
-
-	_, err := hex.Decode(id[0:4], []byte(s[0:8]))
-	if err != nil {
-		return [16]byte{}, err
-	}
-
-	_, err = hex.Decode(id[4:6], []byte(s[9:13]))
-	if err != nil {
-		return [16]byte{}, err
-	}
-
-	_, err = hex.Decode(id[6:8], []byte(s[14:18]))
-	if err != nil {
-		return [16]byte{}, err
-	}
-
-	_, err = hex.Decode(id[8:10], []byte(s[19:23]))
-	if err != nil {
-		return [16]byte{}, err
-	}
-
-	_, err = hex.Decode(id[10:], []byte(s[24:]))
-	if err != nil {
-		return [16]byte{}, err
-	}
-
-	return id, nil
+	return ParseUUID(s)
 }
 
 func (id ULID) MarshalBinary() ([]byte, error) {
@@ -174,7 +174,10 @@ func (id ULID) MarshalBinary() ([]byte, error) {
 
 func (id *ULID) UnmarshalBinary(data []byte) error {
 	if len(data) != len(*id) {
-		return errors.New("invalid data size when unmarshaling").WithErrorCode(InvalidSizeULIDSystemErrorCode)
+		return errors.
+			New("invalid data size when unmarshaling").
+			WithErrorCode(InvalidSizeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidSize)
 	}
 
 	copy((*id)[:], data)
@@ -185,12 +188,21 @@ func (id ULID) Bytes() ([]byte, error) {
 	return id.MarshalBinary()
 }
 
+// UUID returns the canonical UUID string form
 func (id ULID) UUID() string {
-	return unsafe.String(&id.MarshalUUID()[0], uuidStringLength)
+	var buf [uuidStringLength]byte
+	return string(id.AppendUUID(buf[:0]))
 }
 
 func (id ULID) MarshalUUID() []byte {
-	byteSlice := make([]byte, uuidStringLength)
+	return id.AppendUUID(make([]byte, 0, uuidStringLength))
+}
+
+// AppendUUID appends the UUID string form to b
+func (id ULID) AppendUUID(b []byte) []byte {
+	n := len(b)
+	b = slices.Grow(b, uuidStringLength)[:n+uuidStringLength]
+	byteSlice := b[n:]
 
 	hex.Encode(byteSlice[0:8], id[0:4])
 	byteSlice[8] = '-'
@@ -202,7 +214,7 @@ func (id ULID) MarshalUUID() []byte {
 	byteSlice[23] = '-'
 	hex.Encode(byteSlice[24:], id[10:])
 
-	return byteSlice
+	return b
 }
 
 func (id ULID) ULID() string {
@@ -213,42 +225,145 @@ func (id ULID) String() string {
 	return id.EncodeString()
 }
 
+// EncodeString returns the ULID text form; encoding can not fail, every 128 bits value has one
 func (id ULID) EncodeString() string {
-	raw, err := id.MarshalText()
-	if err != nil {
-		return ""
-	}
+	var buf [textEncodedSize]byte
+	return string(id.appendText(buf[:0], encoding))
+}
 
-	return *(*string)(unsafe.Pointer(&raw))
+// EncodeLower returns the lowercase ULID text form, suited for URLs and DNS labels.
+// UnmarshalText accepts both cases
+func (id ULID) EncodeLower() string {
+	return string(id.appendText(make([]byte, 0, textEncodedSize), encodingLower))
 }
 
+// EncodeUUID returns the hyphenless UUID form, 32 lowercase hex chars
 func (id ULID) EncodeUUID() string {
-	buf := make([]byte, uuidStringLength-4)
-	hex.Encode(buf, id[:])
+	var buf [uuidHexLength]byte
+	hex.Encode(buf[:], id[:])
 
-	return *(*string)(unsafe.Pointer(&buf))
+	return string(buf[:])
 }
 
+// Format implements fmt.Formatter:
+//
+//	%s  ULID text            %q  quoted ULID text
+//	%u  UUID                 %l  lowercase ULID text
+//	%x  lowercase hex        %X  uppercase hex; %#x and %#X add the 0x prefix
+//	%v  ULID text with epoch, scope and the DefaultLayout node/counter
+//	%+v %v plus ISO time, scope name and UUID
+//	%#v Go-syntax literal
+//
+// Width and the '-' and '0' flags pad the output.
 func (id ULID) Format(f fmt.State, verb rune) {
+	var blob = make([]byte, 0, 128)
+
 	switch verb {
 	case 's':
-		blob, _ := id.MarshalText()
-		_, _ = f.Write(blob)
+		blob, _ = id.AppendText(blob)
+	case 'q':
+		blob = append(blob, '"')
+		blob, _ = id.AppendText(blob)
+		blob = append(blob, '"')
 	case 'u':
-		blob := id.MarshalUUID()
-		_, _ = f.Write(blob)
+		blob = id.AppendUUID(blob)
+	case 'l':
+		blob, _ = id.AppendTextLower(blob)
+	case 'x', 'X':
+		if f.Flag('#') {
+			blob = append(blob, '0', byte(verb))
+		}
+		blob = hex.AppendEncode(blob, id[:])
+		if verb == 'X' {
+			blob = bytes.ToUpper(blob)
+		}
 	case 'v':
-		scp, _ := id.Scope()
-		_, _ = fmt.Fprintf(f, "%s(epoch=%d;scope=%d)", id.String(), id.Epoch(), scp)
+		switch {
+		case f.Flag('#'):
+			blob = id.appendGoSyntax(blob)
+		default:
+			blob = id.appendBreakdown(blob, f.Flag('+'))
+		}
 	default:
 		_, _ = fmt.Fprintf(f, "%%!%c(ULID=%s)", verb, id.String())
+		return
+	}
+
+	writePadded(f, blob)
+}
+
+// appendBreakdown appends the text form followed by the decoded fields
+func (id ULID) appendBreakdown(b []byte, full bool) []byte {
+	scp, _ := id.Scope()
+
+	b, _ = id.AppendText(b)
+	b = fmt.Appendf(b, "(epoch=%d", id.Epoch())
+	if full {
+		b = fmt.Appendf(b, ";time=%s", id.Time().UTC().Format(time.RFC3339Nano))
+	}
+	b = fmt.Appendf(b, ";scope=%d", scp)
+	if len(DefaultScopeSchema.fields) > 0 {
+		b = append(b, DefaultScopeSchema.Unpack(scp).format(DefaultScopeSchema)...)
+	}
+	if name, ok := ScopeName(scp); ok && full {
+		b = fmt.Appendf(b, ";scope_name=%s", name)
+	}
+	if node, err := id.Node(DefaultLayout); err == nil {
+		b = fmt.Appendf(b, ";node=%d", node)
+	}
+	if counter, err := id.Counter(DefaultLayout); err == nil {
+		b = fmt.Appendf(b, ";counter=%d", counter)
+	}
+	if full {
+		b = append(b, ";uuid="...)
+		b = id.AppendUUID(b)
 	}
+
+	return append(b, ')')
+}
+
+// appendGoSyntax appends the id as a Go literal, e.g. pulid.ULID{0x1, 0x94, ...}
+func (id ULID) appendGoSyntax(b []byte) []byte {
+	b = append(b, "pulid.ULID{"...)
+	for i, v := range id {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = fmt.Appendf(b, "%#x", v)
+	}
+
+	return append(b, '}')
+}
+
+// writePadded writes blob honoring the state width and the '-' and '0' flags
+func writePadded(f fmt.State, blob []byte) {
+	width, ok := f.Width()
+	if !ok || width <= len(blob) {
+		_, _ = f.Write(blob)
+		return
+	}
+
+	pad, fill := width-len(blob), byte(' ')
+	if f.Flag('0') && !f.Flag('-') {
+		fill = '0'
+	}
+
+	padding := bytes.Repeat([]byte{fill}, pad)
+	if f.Flag('-') {
+		_, _ = f.Write(blob)
+		_, _ = f.Write(padding)
+		return
+	}
+
+	_, _ = f.Write(padding)
+	_, _ = f.Write(blob)
 }
 
 func (id *ULID) Scan(src interface{}) (err error) {
 	createFormatError := errors.
 		New("invalid storage format: size must either be 16 bytes or a UUID string").
-		WithErrorCode(InvalidSizeULIDSystemErrorCode)
+		WithErrorCode(InvalidSizeULIDSystemErrorCode).
+		WithNestedError(ErrInvalidSize)
 
 	switch v := src.(type) {
 	case []byte:
@@ -282,7 +397,23 @@ func (id ULID) Value() (driver.Value, error) {
 }
 
 func (id ULID) MarshalText() ([]byte, error) {
-	dst := make([]byte, textEncodedSize)
+	return id.AppendText(make([]byte, 0, textEncodedSize))
+}
+
+// AppendText appends the ULID text form to b
+func (id ULID) AppendText(b []byte) ([]byte, error) {
+	return id.appendText(b, encoding), nil
+}
+
+// AppendTextLower appends the lowercase ULID text form to b
+func (id ULID) AppendTextLower(b []byte) ([]byte, error) {
+	return id.appendText(b, encodingLower), nil
+}
+
+func (id ULID) appendText(b []byte, encoding string) []byte {
+	n := len(b)
+	b = slices.Grow(b, textEncodedSize)[:n+textEncodedSize]
+	dst := b[n:]
 
 	// timestamp
 	dst[0] = encoding[(id[0]&224)>>5]
@@ -314,97 +445,164 @@ func (id ULID) MarshalText() ([]byte, error) {
 	dst[24] = encoding[((id[14]&3)<<3)|((id[15]&224)>>5)]
 	dst[25] = encoding[id[15]&31]
 
-	return dst, nil
+	return b
 }
 
 func (id *ULID) UnmarshalText(v []byte) error {
-	if len(v) == uuidStringLength {
+	if len(v) == textEncodedSize {
+		if !decodeText(id, v) {
+			return textError(v)
+		}
+
+		return nil
+	}
+
+	if DefaultParseOptions.uuidLength(len(v)) {
 		var err error
-		*id, err = UnmarshalUUID(string(v))
+		*id, err = ParseUUID(string(v))
 		return err
 	}
 
-	if len(v) != textEncodedSize {
-		return errors.New("invalid data size").WithErrorCode(InvalidSizeULIDSystemErrorCode)
-	}
-
-	if c2b32[v[0]] == 0xFF ||
-		c2b32[v[1]] == 0xFF ||
-		c2b32[v[2]] == 0xFF ||
-		c2b32[v[3]] == 0xFF ||
-		c2b32[v[4]] == 0xFF ||
-		c2b32[v[5]] == 0xFF ||
-		c2b32[v[6]] == 0xFF ||
-		c2b32[v[7]] == 0xFF ||
-		c2b32[v[8]] == 0xFF ||
-		c2b32[v[9]] == 0xFF ||
-		c2b32[v[10]] == 0xFF ||
-		c2b32[v[11]] == 0xFF ||
-		c2b32[v[12]] == 0xFF ||
-		c2b32[v[13]] == 0xFF ||
-		c2b32[v[14]] == 0xFF ||
-		c2b32[v[15]] == 0xFF ||
-		c2b32[v[16]] == 0xFF ||
-		c2b32[v[17]] == 0xFF ||
-		c2b32[v[18]] == 0xFF ||
-		c2b32[v[19]] == 0xFF ||
-		c2b32[v[20]] == 0xFF ||
-		c2b32[v[21]] == 0xFF ||
-		c2b32[v[22]] == 0xFF ||
-		c2b32[v[23]] == 0xFF ||
-		c2b32[v[24]] == 0xFF ||
-		c2b32[v[25]] == 0xFF {
-		return errors.New("invalid characters").WithErrorCode(InvalidCharsULIDSystemErrorCode)
-	}
-
-	if v[0] > '7' {
-		return errors.New("overflow '%b' > 7", v[0]).WithErrorCode(InvalidSizeULIDSystemErrorCode)
+	return invalidLengthError(parseExpectedULID, v, textEncodedSize)
+}
+
+// decodeText decodes the 26 chars in v in a single pass: each char is looked up once and OR'ed into a mask,
+// so one branch rejects both invalid chars and an overflowing first char. id is left untouched on failure
+func decodeText[T ~string | ~[]byte](id *ULID, v T) bool {
+	_ = v[textEncodedSize-1]
+
+	var (
+		d0  = c2b32[v[0]]
+		d1  = c2b32[v[1]]
+		d2  = c2b32[v[2]]
+		d3  = c2b32[v[3]]
+		d4  = c2b32[v[4]]
+		d5  = c2b32[v[5]]
+		d6  = c2b32[v[6]]
+		d7  = c2b32[v[7]]
+		d8  = c2b32[v[8]]
+		d9  = c2b32[v[9]]
+		d10 = c2b32[v[10]]
+		d11 = c2b32[v[11]]
+		d12 = c2b32[v[12]]
+		d13 = c2b32[v[13]]
+		d14 = c2b32[v[14]]
+		d15 = c2b32[v[15]]
+		d16 = c2b32[v[16]]
+		d17 = c2b32[v[17]]
+		d18 = c2b32[v[18]]
+		d19 = c2b32[v[19]]
+		d20 = c2b32[v[20]]
+		d21 = c2b32[v[21]]
+		d22 = c2b32[v[22]]
+		d23 = c2b32[v[23]]
+		d24 = c2b32[v[24]]
+		d25 = c2b32[v[25]]
+	)
+
+	// invalid chars map to 0xFF; the first char only holds 3 bits
+	if (d0&0xF8)|(d1|d2|d3|d4|d5|d6|d7|d8|d9|d10|d11|d12|d13|d14|d15|d16|d17|d18|d19|d20|d21|d22|d23|d24|d25)&0xE0 != 0 {
+		return false
 	}
 
 	// timestamp (48 bits)
-	(*id)[0] = (c2b32[v[0]] << 5) | c2b32[v[1]]
-	(*id)[1] = (c2b32[v[2]] << 3) | (c2b32[v[3]] >> 2)
-	(*id)[2] = (c2b32[v[3]] << 6) | (c2b32[v[4]] << 1) | (c2b32[v[5]] >> 4)
-	(*id)[3] = (c2b32[v[5]] << 4) | (c2b32[v[6]] >> 1)
-	(*id)[4] = (c2b32[v[6]] << 7) | (c2b32[v[7]] << 2) | (c2b32[v[8]] >> 3)
-	(*id)[5] = (c2b32[v[8]] << 5) | c2b32[v[9]]
+	id[0] = d0<<5 | d1
+	id[1] = d2<<3 | d3>>2
+	id[2] = d3<<6 | d4<<1 | d5>>4
+	id[3] = d5<<4 | d6>>1
+	id[4] = d6<<7 | d7<<2 | d8>>3
+	id[5] = d8<<5 | d9
 
 	// entropy (80 bits)
-	(*id)[6] = (c2b32[v[10]] << 3) | (c2b32[v[11]] >> 2)
-	(*id)[7] = (c2b32[v[11]] << 6) | (c2b32[v[12]] << 1) | (c2b32[v[13]] >> 4)
-	(*id)[8] = (c2b32[v[13]] << 4) | (c2b32[v[14]] >> 1)
-	(*id)[9] = (c2b32[v[14]] << 7) | (c2b32[v[15]] << 2) | (c2b32[v[16]] >> 3)
-	(*id)[10] = (c2b32[v[16]] << 5) | c2b32[v[17]]
-	(*id)[11] = (c2b32[v[18]] << 3) | c2b32[v[19]]>>2
-	(*id)[12] = (c2b32[v[19]] << 6) | (c2b32[v[20]] << 1) | (c2b32[v[21]] >> 4)
-	(*id)[13] = (c2b32[v[21]] << 4) | (c2b32[v[22]] >> 1)
-	(*id)[14] = (c2b32[v[22]] << 7) | (c2b32[v[23]] << 2) | (c2b32[v[24]] >> 3)
-	(*id)[15] = (c2b32[v[24]] << 5) | c2b32[v[25]]
+	id[6] = d10<<3 | d11>>2
+	id[7] = d11<<6 | d12<<1 | d13>>4
+	id[8] = d13<<4 | d14>>1
+	id[9] = d14<<7 | d15<<2 | d16>>3
+	id[10] = d16<<5 | d17
+	id[11] = d18<<3 | d19>>2
+	id[12] = d19<<6 | d20<<1 | d21>>4
+	id[13] = d21<<4 | d22>>1
+	id[14] = d22<<7 | d23<<2 | d24>>3
+	id[15] = d24<<5 | d25
+
+	return true
+}
 
-	return nil
+// textError reports why decodeText rejected v, the slow path
+func textError[T ~string | ~[]byte](v T) error {
+	for i := 0; i < len(v); i++ {
+		if c2b32[v[i]] == 0xFF {
+			return invalidCharError(parseExpectedULID, v, i)
+		}
+	}
+
+	return overflowError(parseExpectedULID, v)
 }
 
-func (id ULID) Epoch() uint64 {
-	return uint64(id[5]) | uint64(id[4])<<8 |
-		uint64(id[3])<<16 | uint64(id[2])<<24 |
-		uint64(id[1])<<32 | uint64(id[0])<<40
+// Epoch returns the Unix milliseconds timestamp according to the layout, DefaultLayout if omitted.
+// Custom layout epochs are added back
+func (id ULID) Epoch(layout ...Layout) uint64 {
+	l := layoutOrDefault(layout)
+
+	return id.readBits(0, uint(l.EpochBits)) + l.epochOffset()
 }
 
-func (id *ULID) setTime(t time.Time) error {
-	ms := uint64(t.Unix())*1000 +
-		uint64(t.Nanosecond()/int(time.Millisecond))
+// Time returns the timestamp according to the layout, DefaultLayout if omitted.
+// Sub millisecond fractions are rounded to the nanosecond
+func (id ULID) Time(layout ...Layout) time.Time {
+	l := layoutOrDefault(layout)
 
-	if ms > maxTime {
-		return errors.New("epoch overflow").WithErrorCode(InvalidTimeFormatULIDSystemErrorCode)
+	var (
+		ms   = id.Epoch(l)
+		frac = id.readBits(uint(l.EpochBits), uint(l.SubMillisBits))
+		ns   uint64
+	)
+
+	if l.SubMillisBits > 0 {
+		ns = (frac*uint64(time.Millisecond) + 1<<(l.SubMillisBits-1)) >> l.SubMillisBits
+	}
+
+	return time.UnixMilli(int64(ms)).Add(time.Duration(ns))
+}
+
+// VerifyEpoch checks the id timestamp, decoded according to the layout, is neither in the future
+// nor before MinPlausibleTime. IDs generated with a different epoch configuration decode far off the current time;
+// e.g. Unix epoch IDs decoded with a 2024 epoch land past 2070, 2024 epoch IDs decoded with the Unix epoch land on the 1970s
+func (id ULID) VerifyEpoch(layout ...Layout) error {
+	var (
+		l     = layoutOrDefault(layout)
+		ts    = id.Time(l)
+		limit = time.Now().Add(MaxClockSkew)
+	)
+
+	if ts.After(limit) {
+		return errors.
+			New("decoded time %s is in the future; id generated with a different epoch configuration?", ts.Format(time.RFC3339)).
+			WithErrorCode(InvalidTimeFormatULIDSystemErrorCode).
+			WithNestedError(ErrInvalidTimeFormat)
+	}
+
+	if ts.Before(MinPlausibleTime) {
+		return errors.
+			New("decoded time %s is before %s; id generated with a different epoch configuration?", ts.Format(time.RFC3339), MinPlausibleTime.Format(time.RFC3339)).
+			WithErrorCode(InvalidTimeFormatULIDSystemErrorCode).
+			WithNestedError(ErrInvalidTimeFormat)
 	}
 
-	(*id)[0] = byte(ms >> 40)
-	(*id)[1] = byte(ms >> 32)
-	(*id)[2] = byte(ms >> 24)
-	(*id)[3] = byte(ms >> 16)
-	(*id)[4] = byte(ms >> 8)
-	(*id)[5] = byte(ms)
+	return nil
+}
+
+func (id *ULID) setTime(ticks uint64, l Layout) error {
+	ms := ticks >> l.SubMillisBits
+	if ms > l.MaxEpoch() {
+		return errors.
+			New("epoch overflow").
+			WithErrorCode(InvalidTimeFormatULIDSystemErrorCode).
+			WithNestedError(ErrInvalidTimeFormat)
+	}
 
+	id.writeBits(0, uint(l.EpochBits), ms)
+	id.writeBits(uint(l.EpochBits), uint(l.SubMillisBits), ticks)
 	return nil
 }
 
@@ -416,7 +614,10 @@ func (id ULID) MarshallUint64() (uint64, error) {
 	}
 
 	if len(raw) != 16 {
-		return 0, errors.New("invalid raw len(%d)", len(raw)).WithErrorCode(InvalidSizeULIDSystemErrorCode)
+		return 0, errors.
+			New("invalid raw len(%d)", len(raw)).
+			WithErrorCode(InvalidSizeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidSize)
 	}
 
 	buf := bytes.NewReader(raw[8:16])
@@ -427,10 +628,16 @@ func (id ULID) MarshallUint64() (uint64, error) {
 	return res, nil
 }
 
-func (id ULID) Scope() (Scope, error) {
-	var scope = binary.BigEndian.Uint16(id[6:8])
+// Scope returns the scope according to the layout, DefaultLayout if omitted
+func (id ULID) Scope(layout ...Layout) (Scope, error) {
+	l := layoutOrDefault(layout)
+
+	var scope = Scope(id.readBits(l.scopeOffset(), uint(l.ScopeBits)))
 	if scope == ZeroedScopeValue {
-		return ZeroedScopeValue, errors.New("invalid scope").WithErrorCode(InvalidScopeULIDSystemErrorCode)
+		return ZeroedScopeValue, errors.
+			New("invalid scope").
+			WithErrorCode(InvalidScopeULIDSystemErrorCode).
+			WithNestedError(ErrInvalidScope)
 	}
 
 	return scope, nil
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -247,6 +249,10 @@ func TestUUIDUniquenessAfterConversion(t *testing.T) {
 }
 
 func TestULIDToUUIDMassiveUniqueness(t *testing.T) {
+	if testing.Short() {
+		t.Skip("generates 10 million ids")
+	}
+
 	const totalIDs = 10_000_000 // 10 million ULIDs
 	uuidSet := make(map[string]struct{}, totalIDs)
 
@@ -273,6 +279,10 @@ func TestULIDToUUIDMassiveUniqueness(t *testing.T) {
 }
 
 func TestConcurrentULIDToUUIDUniqueness(t *testing.T) {
+	if testing.Short() {
+		t.Skip("generates 10 million ids")
+	}
+
 	const totalIDs = 10_000_000 // Total number of ULIDs to generate
 	const numWorkers = 20       // Number of concurrent workers
 	idsPerWorker := totalIDs / numWorkers
@@ -483,4 +493,115 @@ func TestULIDTimestampEdgeCases(t *testing.T) {
 	if fmt.Sprintf("%u", n) != n.UUID() {
 		t.Fatalf("ULID u fromatter and UUID should be equal")
 	}
-}
\ No newline at end of file
+}
+func TestULIDLowercase(t *testing.T) {
+	id := MustNewScoped(567)
+	lower := id.EncodeLower()
+
+	if lower != strings.ToLower(id.String()) {
+		t.Fatalf("Lowercase mismatch: expected %s got %s", strings.ToLower(id.String()), lower)
+	}
+
+	if fmt.Sprintf("%l", id) != lower {
+		t.Fatalf("ULID l formatter and EncodeLower should be equal")
+	}
+
+	appended, _ := id.AppendTextLower([]byte("usr_"))
+	if string(appended) != "usr_"+lower {
+		t.Fatalf("AppendTextLower mismatch: got %s", appended)
+	}
+
+	parsed, err := UnmarshalString(lower)
+	if err != nil || parsed != id {
+		t.Fatalf("Lowercase parsing failed: expected %v got %v; err %+v", id, parsed, err)
+	}
+}
+
+func TestULIDFormatVerbs(t *testing.T) {
+	if err := RegisterScopeName(567, "usr"); err != nil {
+		t.Fatalf("Failed to register scope name: %v", err)
+	}
+
+	id := MustNewScoped(567)
+	hexID := id.Encode(Hex)
+
+	expected := map[string]string{
+		"%s":    id.String(),
+		"%q":    `"` + id.String() + `"`,
+		"%x":    hexID,
+		"%X":    strings.ToUpper(hexID),
+		"%#x":   "0x" + hexID,
+		"%30s":  "    " + id.String(),
+		"%-30s": id.String() + "    ",
+		"%030s": "0000" + id.String(),
+		"%40u":  "    " + id.UUID(),
+	}
+
+	for format, want := range expected {
+		if got := fmt.Sprintf(format, id); got != want {
+			t.Fatalf("Format %s mismatch: expected %s got %s", format, want, got)
+		}
+	}
+
+	full := fmt.Sprintf("%+v", id)
+	for _, part := range []string{
+		id.String() + "(",
+		"time=" + id.Time().UTC().Format(time.RFC3339Nano),
+		"scope=567",
+		"scope_name=usr",
+		"uuid=" + id.UUID(),
+	} {
+		if !strings.Contains(full, part) {
+			t.Fatalf("Format %%+v should contain %s, got %s", part, full)
+		}
+	}
+
+	literal := fmt.Sprintf("%#v", ULID{0x01, 0x94, 0xaa})
+	if literal != "pulid.ULID{0x1, 0x94, 0xaa, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}" {
+		t.Fatalf("Format %%#v mismatch, got %s", literal)
+	}
+}
+
+func TestNewScopedAt(t *testing.T) {
+	at := time.Date(2025, 1, 27, 23, 18, 8, 350*int(time.Millisecond), time.UTC)
+
+	id, err := NewScopedAt(at, 567)
+	if err != nil {
+		t.Fatalf("NewScopedAt failed: %v", err)
+	}
+
+	if scope, _ := id.Scope(); scope != 567 || !id.Time().Equal(at) {
+		t.Fatalf("Expected scope 567 at %s, got %d at %s", at, scope, id.Time())
+	}
+
+	if _, err = NewScopedAt(time.Unix(-1, 0), 567); err == nil {
+		t.Fatalf("Expected error for times before the epoch")
+	}
+}
+
+func TestNewNarrowScopeLayout(t *testing.T) {
+	defer func(l Layout) { DefaultLayout = l }(DefaultLayout)
+	DefaultLayout = Layout{EpochBits: 48, ScopeBits: 10, RandomBits: 70}
+
+	id, err := New()
+	if err != nil {
+		t.Fatalf("New failed on a 10 bits scope layout: %v", err)
+	}
+
+	if scope, _ := id.Scope(); scope != DefaultLayout.MaxScope() {
+		t.Fatalf("Expected the layout max scope %d, got %d", DefaultLayout.MaxScope(), scope)
+	}
+}
+
+func TestInvalidDefaultLayout(t *testing.T) {
+	defer func(l Layout) { DefaultLayout = l }(DefaultLayout)
+	DefaultLayout.RandomBits = 200
+
+	if _, err := NewScoped(567); !errors.Is(err, ErrInvalidLayout) {
+		t.Fatalf("Expected ErrInvalidLayout for an invalid default layout, got %v", err)
+	}
+
+	if _, err := New(); !errors.Is(err, ErrInvalidLayout) {
+		t.Fatalf("Expected ErrInvalidLayout for an invalid default layout, got %v", err)
+	}
+}
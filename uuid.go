@@ -0,0 +1,117 @@
+package pulid
+
+import "encoding/hex"
+
+// ParseOptions toggles the UUID forms accepted besides the canonical, hyphenated, 36 chars one
+type ParseOptions struct {
+	// Braced accepts {0194aa15-34ab-0237-22e7-0b92b8c5f1b7}
+	Braced bool
+	// URN accepts urn:uuid:0194aa15-34ab-0237-22e7-0b92b8c5f1b7, prefix case insensitive
+	URN bool
+	// Hex accepts the 32 chars hyphenless form, 0194aa1534ab023722e70b92b8c5f1b7
+	Hex bool
+}
+
+// AllParseOptions enables every UUID form
+var AllParseOptions = ParseOptions{Braced: true, URN: true, Hex: true}
+
+// uuidLength reports whether n is the length of an enabled UUID form
+func (o ParseOptions) uuidLength(n int) bool {
+	switch n {
+	case uuidStringLength:
+		return true
+	case uuidBracedLength:
+		return o.Braced
+	case uuidURNLength:
+		return o.URN
+	case uuidHexLength:
+		return o.Hex
+	default:
+		return false
+	}
+}
+
+// ParseUUID parses s as a UUID, checking hyphens are at 8, 13, 18 and 23.
+// Alternative forms are accepted per opts, DefaultParseOptions if omitted
+func ParseUUID(s string, opts ...ParseOptions) (ULID, error) {
+	var o = DefaultParseOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	switch {
+	case len(s) == uuidStringLength:
+		return parseCanonicalUUID(s, 0)
+	case len(s) == uuidBracedLength && o.Braced:
+		if s[0] != '{' {
+			return EmptyUID, invalidCharError(parseExpectedUUID, s, 0)
+		}
+
+		if s[len(s)-1] != '}' {
+			return EmptyUID, invalidCharError(parseExpectedUUID, s, len(s)-1)
+		}
+
+		return parseCanonicalUUID(s, 1)
+	case len(s) == uuidURNLength && o.URN:
+		for i := 0; i < len(uuidURNPrefix); i++ {
+			if lower(s[i]) != uuidURNPrefix[i] {
+				return EmptyUID, invalidCharError(parseExpectedUUID, s, i)
+			}
+		}
+
+		return parseCanonicalUUID(s, len(uuidURNPrefix))
+	case len(s) == uuidHexLength && o.Hex:
+		var id ULID
+		for i := 0; i < len(s); i++ {
+			if !isHex(s[i]) {
+				return EmptyUID, invalidCharError(parseExpectedUUID, s, i)
+			}
+		}
+
+		_, _ = hex.Decode(id[:], []byte(s))
+		return id, nil
+	default:
+		return EmptyUID, invalidLengthError(parseExpectedUUID, s, uuidStringLength)
+	}
+}
+
+// parseCanonicalUUID decodes the 36 chars UUID starting at offset of s; errors index s
+func parseCanonicalUUID(s string, offset int) (ULID, error) {
+	var (
+		id  ULID
+		src = s[offset : offset+uuidStringLength]
+	)
+
+	for i := 0; i < len(src); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if src[i] != '-' {
+				return EmptyUID, invalidCharError(parseExpectedUUID, s, offset+i)
+			}
+		default:
+			if !isHex(src[i]) {
+				return EmptyUID, invalidCharError(parseExpectedUUID, s, offset+i)
+			}
+		}
+	}
+
+	_, _ = hex.Decode(id[0:4], []byte(src[0:8]))
+	_, _ = hex.Decode(id[4:6], []byte(src[9:13]))
+	_, _ = hex.Decode(id[6:8], []byte(src[14:18]))
+	_, _ = hex.Decode(id[8:10], []byte(src[19:23]))
+	_, _ = hex.Decode(id[10:], []byte(src[24:]))
+
+	return id, nil
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func lower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+
+	return c
+}
@@ -0,0 +1,112 @@
+package pulid
+
+import (
+	goErrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseUUIDForms(t *testing.T) {
+	id := MustNewScoped(567)
+	canonical := id.UUID()
+
+	forms := map[string]ParseOptions{
+		"{" + canonical + "}":                    {Braced: true},
+		"urn:uuid:" + canonical:                  {URN: true},
+		"URN:UUID:" + strings.ToUpper(canonical): {URN: true},
+		strings.ReplaceAll(canonical, "-", ""):   {Hex: true},
+	}
+
+	for input, opts := range forms {
+		if _, err := ParseUUID(input); err == nil {
+			t.Fatalf("Form %s should be rejected by default", input)
+		}
+
+		parsed, err := ParseUUID(input, opts)
+		if err != nil || parsed != id {
+			t.Fatalf("Form %s should parse to %v, got %v; err %+v", input, id, parsed, err)
+		}
+	}
+
+	if parsed, err := ParseUUID(canonical, ParseOptions{}); err != nil || parsed != id {
+		t.Fatalf("Canonical form should always parse, got %v; err %+v", parsed, err)
+	}
+}
+
+func TestParseUUIDStrict(t *testing.T) {
+	invalid := map[string]int{
+		"0194aa15_34ab-0237-22e7-0b92b8c5f1b7":          8,
+		"0194aa15-34ab+0237-22e7-0b92b8c5f1b7":          13,
+		"0194aa1534ab-02377-22e7-0b92b8c5f1b7":          8,
+		"0194aa15-34ab-0237-22e7f0b92b8c5f1b7":          23,
+		"(0194aa15-34ab-0237-22e7-0b92b8c5f1b7}":        0,
+		"{0194aa15-34ab-0237-22e7-0b92b8c5f1b7)":        37,
+		"urn:uuix:0194aa15-34ab-0237-22e7-0b92b8c5f1b7": 7,
+		"urn:uuid:0194aa15-34ab-0237-22e7-0b92b8c5f1bx": 44,
+	}
+
+	for input, index := range invalid {
+		_, err := ParseUUID(input, AllParseOptions)
+
+		var pe *ParseError
+		if !goErrors.As(err, &pe) || pe.Index != index || !goErrors.Is(err, ErrInvalidChars) {
+			t.Fatalf("Expected invalid character at %d for %s, got %+v", index, input, err)
+		}
+	}
+
+	// UnmarshalText follows DefaultParseOptions
+	defer func(opts ParseOptions) { DefaultParseOptions = opts }(DefaultParseOptions)
+	DefaultParseOptions = ParseOptions{Braced: true}
+
+	id := MustNew()
+	parsed, err := UnmarshalString("{" + id.UUID() + "}")
+	if err != nil || parsed != id {
+		t.Fatalf("Braced UUID should parse through UnmarshalText, got %v; err %+v", parsed, err)
+	}
+}
+
+func FuzzParseUUID(f *testing.F) {
+	id := MustNewScoped(567)
+	for _, seed := range []string{
+		id.UUID(),
+		"{" + id.UUID() + "}",
+		"urn:uuid:" + id.UUID(),
+		strings.ReplaceAll(id.UUID(), "-", ""),
+		"0194aa15_34ab-0237-22e7-0b92b8c5f1b7",
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		parsed, err := ParseUUID(s, AllParseOptions)
+
+		// differential: google/uuid accepts the same forms, but skips the braces check
+		expected, expectedErr := uuid.Parse(s)
+		if len(s) == uuidBracedLength && (s[0] != '{' || s[len(s)-1] != '}') {
+			expectedErr = goErrors.New("not braced")
+		}
+
+		if (err == nil) != (expectedErr == nil) {
+			t.Fatalf("Parse %q: pulid err %v, google/uuid err %v", s, err, expectedErr)
+		}
+
+		if err != nil {
+			var pe *ParseError
+			if !goErrors.As(err, &pe) || pe.Input != s || pe.Index < 0 || pe.Index > len(s) {
+				t.Fatalf("Parse %q: expected ParseError within the input, got %+v", s, err)
+			}
+			return
+		}
+
+		if parsed != ULID(expected) {
+			t.Fatalf("Parse %q: expected %v got %v", s, ULID(expected), parsed)
+		}
+
+		if again, err := ParseUUID(parsed.UUID()); err != nil || again != parsed {
+			t.Fatalf("Round trip of %q failed: %v; err %+v", s, again, err)
+		}
+	})
+}